@@ -0,0 +1,128 @@
+// Code generated by amp-attrgen from amp.proto. DO NOT EDIT.
+
+package amp
+
+import (
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+func (v *Login) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Login) TagSpec() tag.Spec {
+	return AttrSpec.With("Login")
+}
+
+func (v *Login) New() tag.Value {
+	return &Login{}
+}
+
+func (v *LoginChallenge) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginChallenge) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginChallenge")
+}
+
+func (v *LoginChallenge) New() tag.Value {
+	return &LoginChallenge{}
+}
+
+func (v *LoginResponse) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginResponse) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginResponse")
+}
+
+func (v *LoginResponse) New() tag.Value {
+	return &LoginResponse{}
+}
+
+func (v *LoginCheckpoint) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LoginCheckpoint) TagSpec() tag.Spec {
+	return AttrSpec.With("LoginCheckpoint")
+}
+
+func (v *LoginCheckpoint) New() tag.Value {
+	return &LoginCheckpoint{}
+}
+
+func (v *PinRequest) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *PinRequest) TagSpec() tag.Spec {
+	return AttrSpec.With("PinRequest")
+}
+
+func (v *PinRequest) New() tag.Value {
+	return &PinRequest{}
+}
+
+func (v *LaunchURL) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *LaunchURL) TagSpec() tag.Spec {
+	return AttrSpec.With("LaunchURL")
+}
+
+func (v *LaunchURL) New() tag.Value {
+	return &LaunchURL{}
+}
+
+func (v *Tag) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Tag) TagSpec() tag.Spec {
+	return AttrSpec.With("Tag")
+}
+
+func (v *Tag) New() tag.Value {
+	return &Tag{}
+}
+
+func (v *AuthToken) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *AuthToken) TagSpec() tag.Spec {
+	return AttrSpec.With("AuthToken")
+}
+
+func (v *AuthToken) New() tag.Value {
+	return &AuthToken{}
+}
+
+func (v *Err) MarshalToStore(in []byte) (out []byte, err error) {
+	return MarshalPbToStore(v, in)
+}
+
+func (v *Err) TagSpec() tag.Spec {
+	return AttrSpec.With("Err")
+}
+
+func (v *Err) New() tag.Value {
+	return &Err{}
+}
+
+// registerAttrs registers a prototype of each attr declared in amp.proto.
+func registerAttrs(reg Registry) {
+	reg.RegisterPrototype(AttrSpec, &Login{}, "Login")
+	reg.RegisterPrototype(AttrSpec, &LoginChallenge{}, "LoginChallenge")
+	reg.RegisterPrototype(AttrSpec, &LoginResponse{}, "LoginResponse")
+	reg.RegisterPrototype(AttrSpec, &LoginCheckpoint{}, "LoginCheckpoint")
+	reg.RegisterPrototype(AttrSpec, &PinRequest{}, "PinRequest")
+	reg.RegisterPrototype(AttrSpec, &LaunchURL{}, "LaunchURL")
+	reg.RegisterPrototype(AttrSpec, &Tag{}, "Tag")
+	reg.RegisterPrototype(AttrSpec, &AuthToken{}, "AuthToken")
+	reg.RegisterPrototype(AttrSpec, &Err{}, "Err")
+}
@@ -1,6 +1,10 @@
 package amp
 
+//go:generate go run github.com/amp-3d/amp-sdk-go/cmd/amp-attrgen -register registerAttrs amp.proto
+
 import (
+	"errors"
+
 	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
 )
 
@@ -16,22 +20,7 @@ var (
 )
 
 func RegisterBuiltinTypes(reg Registry) error {
-
-	prototypes := []tag.Value{
-		&Err{},
-		&Tag{},
-		&LaunchURL{},
-		&Login{},
-		&LoginChallenge{},
-		&LoginResponse{},
-		&LoginCheckpoint{},
-		&PinRequest{},
-	}
-
-	for _, pi := range prototypes {
-		reg.RegisterPrototype(AttrSpec, pi, "")
-	}
-
+	registerAttrs(reg)
 	return nil
 }
 
@@ -52,26 +41,14 @@ func ErrorToValue(v error) tag.Value {
 	if v == nil {
 		return nil
 	}
-	arcErr, _ := v.(*Err)
-	if arcErr == nil {
+	var arcErr *Err
+	if !errors.As(v, &arcErr) {
 		wrapped := ErrCode_UnnamedErr.Wrap(v)
 		arcErr = wrapped.(*Err)
 	}
 	return arcErr
 }
 
-func (v *Tag) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Tag) TagSpec() tag.Spec {
-	return AttrSpec.With("Tag")
-}
-
-func (v *Tag) New() tag.Value {
-	return &Tag{}
-}
-
 func (v *Tag) TagID() tag.ID {
 	return [3]uint64{
 		uint64(v.TagID_0),
@@ -91,102 +68,6 @@ func (v *Tag) SetTagID(tagID tag.ID) {
 // 	v.Timestamp = int64(tag[0])
 // }
 
-func (v *Err) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Err) TagSpec() tag.Spec {
-	return AttrSpec.With("Err")
-}
-
-func (v *Err) New() tag.Value {
-	return &Err{}
-}
-
-func (v *LaunchURL) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LaunchURL) TagSpec() tag.Spec {
-	return AttrSpec.With("LaunchURL")
-}
-
-func (v *LaunchURL) New() tag.Value {
-	return &LaunchURL{}
-}
-
-func (v *Login) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *Login) TagSpec() tag.Spec {
-	return AttrSpec.With("Login")
-}
-
-func (v *Login) New() tag.Value {
-	return &Login{}
-}
-
-func (v *LoginChallenge) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginChallenge) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginChallenge")
-}
-
-func (v *LoginChallenge) New() tag.Value {
-	return &LoginChallenge{}
-}
-
-func (v *LoginResponse) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginResponse) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginResponse")
-}
-
-func (v *LoginResponse) New() tag.Value {
-	return &LoginResponse{}
-}
-
-func (v *LoginCheckpoint) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *LoginCheckpoint) TagSpec() tag.Spec {
-	return AttrSpec.With("LoginCheckpoint")
-}
-
-func (v *LoginCheckpoint) New() tag.Value {
-	return &LoginCheckpoint{}
-}
-
-func (v *AuthToken) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *AuthToken) TagSpec() tag.Spec {
-	return AttrSpec.With("spec.type")
-}
-
-func (v *AuthToken) New() tag.Value {
-	return &AuthToken{}
-}
-
-func (v *PinRequest) MarshalToStore(in []byte) (out []byte, err error) {
-	return MarshalPbToStore(v, in)
-}
-
-func (v *PinRequest) TagSpec() tag.Spec {
-	return AttrSpec.With("PinRequest")
-}
-
-func (v *PinRequest) New() tag.Value {
-	return &PinRequest{}
-}
-
 func (v *PinRequest) SetTargetID(id tag.ID) {
 	if v.PinTarget == nil {
 		v.PinTarget = &Tag{}
@@ -208,18 +89,88 @@ func (v *PinRequest) TargetID() tag.ID {
 	}
 }
 
-/*
-func (v *Request) AttrsToPin() map[tag.ID]struct{} {
-	pinAttrs := make(map[tag.ID]struct{}, len(v.PinAttrs))
-	for _, attr := range v.PinAttrs {
-		attrID := attr.AttrID()
-		if attrID.IsNil() && attr.URL != "" {
-			attrID = tag.FormSpec(tag.Spec{}, attr.URL).ID
+// NewPinAttr returns an element of PinRequest.PinAttrs that includes or excludes the given attr.
+// attrID may be tag.Wildcard to select all attrs, or the ID of a cell property (an SI of CellProperties).
+// Alternatively, a client may set Tag.URL to an attr spec (e.g. "amp.tag.attr.Tag") in place of its tag.ID.
+func NewPinAttr(attrID tag.ID, op SelectOp) *Tag {
+	pinAttr := &Tag{
+		SelectOp: op,
+	}
+	pinAttr.SetTagID(attrID)
+	return pinAttr
+}
+
+// AttrSelector reports which attrs are selected by PinRequest.PinAttrs -- a nil *AttrSelector selects all attrs.
+type AttrSelector struct {
+	includeAll bool
+	include    map[tag.ID]struct{}
+	exclude    map[tag.ID]struct{}
+}
+
+// AttrsToPin returns the attrs selected by PinAttrs, or nil if all attrs are selected.
+//
+// An attr is selected if it is included (SelectOp_Include or SelectOp_Neutral) and not excluded (SelectOp_Exclude).
+// If PinAttrs includes tag.Wildcard or only excludes attrs, all attrs not excluded are included.  Excluding
+// tag.Wildcard selects only the attrs explicitly included.
+func (v *PinRequest) AttrsToPin() *AttrSelector {
+	if len(v.PinAttrs) == 0 {
+		return nil
+	}
+
+	sel := &AttrSelector{}
+	excludeAll := false
+	for _, pinAttr := range v.PinAttrs {
+		attrID := pinAttr.TagID()
+		if attrID.IsNil() && pinAttr.URL != "" {
+			attrID = tag.Spec{}.With(pinAttr.URL).ID
 		}
-		if !attrID.IsNil() {
-			pinAttrs[attrID] = struct{}{}
+		switch {
+		case attrID.IsWildcard():
+			if pinAttr.SelectOp == SelectOp_Exclude {
+				excludeAll = true
+			} else {
+				sel.includeAll = true
+			}
+		case pinAttr.SelectOp == SelectOp_Exclude:
+			if sel.exclude == nil {
+				sel.exclude = make(map[tag.ID]struct{})
+			}
+			sel.exclude[attrID] = struct{}{}
+		default:
+			if sel.include == nil {
+				sel.include = make(map[tag.ID]struct{})
+			}
+			sel.include[attrID] = struct{}{}
 		}
 	}
-	return pinAttrs
+	if excludeAll {
+		sel.includeAll = false
+	} else if sel.include == nil {
+		sel.includeAll = true
+	}
+	if sel.includeAll && sel.exclude == nil {
+		return nil
+	}
+	return sel
+}
+
+// Selects returns true if an op having the given AttrID and SI is selected, where either may match a pinned attr.
+func (sel *AttrSelector) Selects(attrID, SI tag.ID) bool {
+	if sel == nil {
+		return true
+	}
+	if _, excluded := sel.exclude[attrID]; excluded {
+		return false
+	}
+	if _, excluded := sel.exclude[SI]; excluded && SI.IsSet() {
+		return false
+	}
+	if sel.includeAll {
+		return true
+	}
+	_, included := sel.include[attrID]
+	if !included && SI.IsSet() {
+		_, included = sel.include[SI]
+	}
+	return included
 }
-*/
@@ -11,6 +11,7 @@ import (
 	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	descriptor "github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -123,11 +124,12 @@ const (
 	//- The purpose of an EditID is to safely determine its predecessor EditID, allowing a tree of revisions to be reconstructed.
 	//- In effect, a sorted list of CellID / AttrID / SI / EditID form a CRDT -- EditID maps to "height" described in https://peerlinks.io/protocol.html
 	//- EditIDs are increasingly negative in time, causing the newest revisions to appear first in a sorted list of TxIDs
+	//- The high bits of EditID[1] are taken from the entropy of newTxID so that concurrent edits made at the same time are distinct
 	//- Although EditIDs are naturally 24 bytes, the EditID is truncated to 16 bytes for storage efficiency since is sufficiently unique.
 	//
 	//EditID := tag.ID{
 	//- newTxID[0],
-	//prevEditID[1] ^ (newTxID[0] - prevEditID[0]),
+	//(prevEditID[1] ^ (newTxID[0] - prevEditID[0])) & 0xFFFFFFFF | (newTxID[1] ^ newTxID[2]) << 32,
 	//0,
 	//}
 	TxField_EditID_0  TxField = 10
@@ -989,26 +991,28 @@ func (m *LaunchURL) GetURL() string {
 //
 // Often used to reference an asset, a Link can reference any resource, a show, project, episode, or XR beacon.
 // The tagging naming convention describes a semi-ordered list of UTF tags.
-//      As tags first appear when going from left to right in the list, they are considered "more significant" or "higher priority" than tags that appear later.
-//      It is up to amp-search-dev-tag-specification to order search results based on tag filters (case sensitive, time ranges, or any UTF8 enum identifier)
-//      By convention, tags are case sensitive by default, however there are many filter presets -- This is how people "type or speak search"
-//      "Two tag rule" -- if you can think of two or more other tags in an order ranking, then do that instead.
+//
+//	As tags first appear when going from left to right in the list, they are considered "more significant" or "higher priority" than tags that appear later.
+//	It is up to amp-search-dev-tag-specification to order search results based on tag filters (case sensitive, time ranges, or any UTF8 enum identifier)
+//	By convention, tags are case sensitive by default, however there are many filter presets -- This is how people "type or speak search"
+//	"Two tag rule" -- if you can think of two or more other tags in an order ranking, then do that instead.
 type Tag struct {
 	// Identifies a specific target tag ID this link points to.
-	TagID_0      int64   `protobuf:"varint,2,opt,name=TagID_0,json=TagID0,proto3" json:"TagID_0,omitempty"`
-	TagID_1      uint64  `protobuf:"fixed64,3,opt,name=TagID_1,json=TagID1,proto3" json:"TagID_1,omitempty"`
-	TagID_2      uint64  `protobuf:"fixed64,4,opt,name=TagID_2,json=TagID2,proto3" json:"TagID_2,omitempty"`
-	Text         string  `protobuf:"bytes,7,opt,name=Text,proto3" json:"Text,omitempty"`
-	Ordering     float32 `protobuf:"fixed32,9,opt,name=Ordering,proto3" json:"Ordering,omitempty"`
-	URL          string  `protobuf:"bytes,13,opt,name=URL,proto3" json:"URL,omitempty"`
-	ContentType  string  `protobuf:"bytes,14,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
-	LanguageCode uint32  `protobuf:"varint,18,opt,name=LanguageCode,proto3" json:"LanguageCode,omitempty"`
-	RegionCode   uint32  `protobuf:"varint,19,opt,name=RegionCode,proto3" json:"RegionCode,omitempty"`
-	Metric       Metric  `protobuf:"varint,26,opt,name=Metric,proto3,enum=amp.Metric" json:"Metric,omitempty"`
-	SizeX        uint64  `protobuf:"varint,27,opt,name=SizeX,proto3" json:"SizeX,omitempty"`
-	SizeY        uint64  `protobuf:"varint,28,opt,name=SizeY,proto3" json:"SizeY,omitempty"`
-	SizeZ        uint64  `protobuf:"varint,29,opt,name=SizeZ,proto3" json:"SizeZ,omitempty"`
-	Tags         []*Tag  `protobuf:"bytes,32,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	TagID_0      int64    `protobuf:"varint,2,opt,name=TagID_0,json=TagID0,proto3" json:"TagID_0,omitempty"`
+	TagID_1      uint64   `protobuf:"fixed64,3,opt,name=TagID_1,json=TagID1,proto3" json:"TagID_1,omitempty"`
+	TagID_2      uint64   `protobuf:"fixed64,4,opt,name=TagID_2,json=TagID2,proto3" json:"TagID_2,omitempty"`
+	Text         string   `protobuf:"bytes,7,opt,name=Text,proto3" json:"Text,omitempty"`
+	Ordering     float32  `protobuf:"fixed32,9,opt,name=Ordering,proto3" json:"Ordering,omitempty"`
+	URL          string   `protobuf:"bytes,13,opt,name=URL,proto3" json:"URL,omitempty"`
+	ContentType  string   `protobuf:"bytes,14,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	LanguageCode uint32   `protobuf:"varint,18,opt,name=LanguageCode,proto3" json:"LanguageCode,omitempty"`
+	RegionCode   uint32   `protobuf:"varint,19,opt,name=RegionCode,proto3" json:"RegionCode,omitempty"`
+	Metric       Metric   `protobuf:"varint,26,opt,name=Metric,proto3,enum=amp.Metric" json:"Metric,omitempty"`
+	SizeX        uint64   `protobuf:"varint,27,opt,name=SizeX,proto3" json:"SizeX,omitempty"`
+	SizeY        uint64   `protobuf:"varint,28,opt,name=SizeY,proto3" json:"SizeY,omitempty"`
+	SizeZ        uint64   `protobuf:"varint,29,opt,name=SizeZ,proto3" json:"SizeZ,omitempty"`
+	Tags         []*Tag   `protobuf:"bytes,32,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	SelectOp     SelectOp `protobuf:"varint,40,opt,name=SelectOp,proto3,enum=amp.SelectOp" json:"SelectOp,omitempty"`
 }
 
 func (m *Tag) Reset()      { *m = Tag{} }
@@ -1141,6 +1145,13 @@ func (m *Tag) GetTags() []*Tag {
 	return nil
 }
 
+func (m *Tag) GetSelectOp() SelectOp {
+	if m != nil {
+		return m.SelectOp
+	}
+	return SelectOp_Neutral
+}
+
 type CryptoKey struct {
 	CryptoKitID CryptoKitID `protobuf:"varint,1,opt,name=CryptoKitID,proto3,enum=amp.CryptoKitID" json:"CryptoKitID,omitempty"`
 	KeyBytes    []byte      `protobuf:"bytes,4,opt,name=KeyBytes,proto3" json:"KeyBytes,omitempty"`
@@ -1323,6 +1334,15 @@ func (m *Err) GetMsg() string {
 	return ""
 }
 
+var E_Attr = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MessageOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         50420,
+	Name:          "amp.attr",
+	Tag:           "bytes,50420,opt,name=attr",
+	Filename:      "amp/amp.proto",
+}
+
 func init() {
 	proto.RegisterEnum("amp.Const", Const_name, Const_value)
 	proto.RegisterEnum("amp.TxOpCode", TxOpCode_name, TxOpCode_value)
@@ -1347,135 +1367,144 @@ func init() {
 	proto.RegisterType((*CryptoKey)(nil), "amp.CryptoKey")
 	proto.RegisterType((*AuthToken)(nil), "amp.AuthToken")
 	proto.RegisterType((*Err)(nil), "amp.Err")
+	proto.RegisterExtension(E_Attr)
 }
 
 func init() { proto.RegisterFile("amp/amp.proto", fileDescriptor_7e479d288f92766f) }
 
 var fileDescriptor_7e479d288f92766f = []byte{
-	// 1966 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x98, 0xcf, 0x8f, 0x1b, 0x49,
-	0x15, 0xc7, 0xa7, 0x6d, 0x8f, 0x67, 0x5c, 0xf3, 0x23, 0x35, 0x95, 0x99, 0xa4, 0x37, 0x3b, 0xf1,
-	0x5a, 0x26, 0x8b, 0x47, 0x16, 0x9b, 0x8d, 0x1d, 0xf6, 0xc0, 0x71, 0x62, 0x3b, 0x89, 0xb5, 0xf3,
-	0x8b, 0xb6, 0x27, 0xb0, 0x01, 0xad, 0x55, 0x71, 0x3f, 0xf7, 0xb4, 0xd2, 0xae, 0x6a, 0xaa, 0xcb,
-	0x83, 0x27, 0x27, 0x2e, 0x88, 0xe5, 0xe7, 0x2e, 0x20, 0x71, 0xe2, 0xc7, 0x5e, 0x80, 0x65, 0x0f,
-	0x88, 0x3f, 0x80, 0x05, 0x09, 0x84, 0xb4, 0xe2, 0x94, 0xe3, 0x6a, 0xb9, 0x90, 0xc9, 0x85, 0x03,
-	0x48, 0xb9, 0x23, 0x24, 0x54, 0xd5, 0x3f, 0xdc, 0xed, 0x9d, 0x53, 0xde, 0xfb, 0x7c, 0x5f, 0xfd,
-	0x78, 0xaf, 0x5e, 0x57, 0x79, 0x82, 0xd6, 0xe8, 0xd8, 0x7f, 0x9d, 0x8e, 0xfd, 0x9b, 0xbe, 0xe0,
-	0x92, 0x93, 0x3c, 0x1d, 0xfb, 0xd5, 0x77, 0x73, 0xa8, 0xd8, 0x9f, 0x76, 0xd9, 0x88, 0x93, 0x57,
-	0x51, 0xb1, 0x27, 0xa9, 0x9c, 0x04, 0x66, 0xae, 0x62, 0xec, 0xac, 0x37, 0xd7, 0x6e, 0xaa, 0xd8,
-	0x43, 0x3f, 0x84, 0x56, 0x24, 0x12, 0x13, 0x2d, 0x1d, 0xfa, 0x2d, 0x3e, 0x61, 0xd2, 0x2c, 0x54,
-	0x8c, 0x9d, 0x82, 0x15, 0xbb, 0xe4, 0x15, 0xb4, 0x72, 0x0f, 0x18, 0x04, 0x6e, 0xd0, 0x6d, 0x0f,
-	0x6e, 0x99, 0x8b, 0x15, 0x63, 0x27, 0x6f, 0xa1, 0x04, 0xdd, 0xca, 0x06, 0x34, 0xcc, 0x62, 0xc5,
-	0xd8, 0x29, 0xa6, 0x02, 0x1a, 0xd9, 0x80, 0xa6, 0xb9, 0x34, 0x17, 0xd0, 0x54, 0x01, 0x2d, 0xce,
-	0x24, 0x4c, 0xa5, 0x5e, 0x02, 0x85, 0x4b, 0x24, 0xe8, 0x56, 0x36, 0xa0, 0x61, 0xae, 0x84, 0x33,
-	0x24, 0xa8, 0x91, 0x0d, 0x68, 0x9a, 0xab, 0x73, 0x01, 0xcd, 0xea, 0x3f, 0x0c, 0xb4, 0xb8, 0xc7,
-	0x1d, 0x97, 0x91, 0x6d, 0x54, 0x3a, 0x0e, 0x40, 0xec, 0xd1, 0x47, 0xe0, 0x99, 0x46, 0xc5, 0xd8,
-	0x29, 0x59, 0x33, 0x40, 0xaa, 0x68, 0x49, 0x39, 0xc7, 0xdd, 0xb6, 0xae, 0xd7, 0x4a, 0x73, 0x59,
-	0xd7, 0xab, 0x4f, 0x1d, 0x2b, 0x16, 0xd4, 0x0c, 0x6d, 0x38, 0x75, 0x87, 0xa0, 0xa2, 0x16, 0xc3,
-	0x19, 0x12, 0x40, 0x2a, 0x68, 0x25, 0x74, 0xc2, 0x15, 0x8a, 0x5a, 0x4f, 0x23, 0x72, 0x0d, 0x2d,
-	0xdf, 0xe7, 0x81, 0xdc, 0xb5, 0x6d, 0x61, 0x2e, 0x6b, 0x39, 0xf1, 0xc9, 0x17, 0x11, 0x6a, 0x9d,
-	0xc0, 0xf0, 0xb1, 0xcf, 0x5d, 0x26, 0x75, 0xa9, 0x56, 0x9a, 0x9b, 0x7a, 0x0b, 0x7a, 0xf7, 0x33,
-	0xcd, 0x4a, 0xc5, 0x55, 0x6f, 0xa0, 0xf5, 0x48, 0xa6, 0x9e, 0x07, 0xcc, 0x01, 0x42, 0x50, 0xe1,
-	0x3e, 0x0d, 0x4e, 0x74, 0x82, 0xab, 0x96, 0xb6, 0xab, 0xb7, 0xd1, 0x9a, 0x8e, 0xb2, 0x20, 0xf0,
-	0x39, 0x0b, 0x80, 0x54, 0xd1, 0xaa, 0x12, 0x62, 0x3f, 0x0a, 0xce, 0xb0, 0xea, 0x97, 0xd1, 0xa5,
-	0xb9, 0x95, 0x55, 0xfe, 0xbb, 0x13, 0x79, 0xd2, 0xe7, 0x8f, 0x81, 0xc5, 0x15, 0x4c, 0x80, 0xca,
-	0x5f, 0x39, 0x9d, 0xa9, 0xef, 0x0a, 0x08, 0xbb, 0x2e, 0x6f, 0xa5, 0x51, 0xf5, 0x1d, 0x03, 0xa1,
-	0x23, 0xb5, 0x8d, 0x6f, 0x4c, 0x20, 0x90, 0xe4, 0xf3, 0xa8, 0x74, 0xe4, 0xb2, 0x3e, 0x15, 0x0e,
-	0xc8, 0xcf, 0x14, 0x7d, 0x26, 0x91, 0x1b, 0x68, 0xf9, 0xc8, 0x65, 0xbb, 0x52, 0x8a, 0xc0, 0x2c,
-	0x54, 0xf2, 0x99, 0xb0, 0x44, 0x21, 0x5f, 0x40, 0x25, 0xd5, 0xd2, 0xd0, 0x3b, 0x63, 0x43, 0x5d,
-	0xfc, 0xf5, 0xe6, 0xba, 0x0e, 0x4b, 0xa8, 0x35, 0x0b, 0xa8, 0x5e, 0x47, 0xa5, 0x3d, 0x3a, 0x61,
-	0xc3, 0x93, 0x63, 0x6b, 0x8f, 0x60, 0x94, 0x3f, 0xb6, 0xf6, 0xa2, 0x8c, 0x94, 0x59, 0xfd, 0x5f,
-	0x0e, 0xe5, 0xfb, 0xd4, 0x21, 0x57, 0xd1, 0x52, 0x9f, 0x3a, 0xba, 0x39, 0xc3, 0x7c, 0x8a, 0xda,
-	0xbd, 0x35, 0x13, 0x1a, 0x66, 0x5e, 0xf7, 0x5c, 0x28, 0x34, 0x66, 0x42, 0x53, 0x7f, 0x4f, 0xb1,
-	0xd0, 0x54, 0x07, 0xd3, 0x87, 0x69, 0x78, 0xb4, 0x25, 0x4b, 0xdb, 0xaa, 0x21, 0x0e, 0x85, 0x0d,
-	0xc2, 0x65, 0x8e, 0x59, 0xaa, 0x18, 0x3b, 0x39, 0x2b, 0xf1, 0xe3, 0x4d, 0xad, 0x25, 0x9b, 0x52,
-	0x05, 0xd6, 0x8d, 0xcd, 0x64, 0xff, 0xcc, 0x07, 0x73, 0x3d, 0x6c, 0xb0, 0x14, 0x52, 0xe7, 0xba,
-	0x47, 0x99, 0x33, 0xa1, 0x0e, 0xb4, 0xb8, 0x0d, 0x26, 0xa9, 0x18, 0x3b, 0x6b, 0x56, 0x86, 0x91,
-	0x32, 0x42, 0x16, 0x38, 0x2e, 0x67, 0x3a, 0xe2, 0xb2, 0x8e, 0x48, 0x11, 0xf2, 0x39, 0x54, 0xdc,
-	0x07, 0x29, 0xdc, 0xa1, 0x79, 0x4d, 0x17, 0x71, 0x45, 0x17, 0x31, 0x44, 0x56, 0x24, 0x91, 0x4d,
-	0xb4, 0xd8, 0x73, 0x9f, 0xc0, 0x57, 0xcd, 0x97, 0xf5, 0x9d, 0x11, 0x3a, 0x31, 0x7d, 0xcb, 0xdc,
-	0x9e, 0xd1, 0xb7, 0x62, 0xfa, 0xd0, 0xbc, 0x3e, 0xa3, 0x0f, 0xc9, 0x36, 0x2a, 0xf4, 0xa9, 0x13,
-	0x98, 0x95, 0xb9, 0x03, 0xd5, 0xb4, 0xfa, 0x35, 0x54, 0x6a, 0x89, 0x33, 0x5f, 0xf2, 0x37, 0xe1,
-	0x8c, 0x34, 0xd1, 0x4a, 0xe4, 0xb8, 0xb2, 0xdb, 0xd6, 0xc7, 0xb4, 0xde, 0xc4, 0x7a, 0x44, 0x8a,
-	0x5b, 0xe9, 0x20, 0x55, 0xd9, 0x37, 0xe1, 0xec, 0xce, 0x99, 0x84, 0x40, 0x9f, 0xc3, 0xaa, 0x95,
-	0xf8, 0xd5, 0xef, 0x18, 0x68, 0xae, 0x6d, 0x87, 0x43, 0x08, 0x82, 0x74, 0x5b, 0xa7, 0x91, 0x6a,
-	0x7b, 0x6d, 0xe8, 0xaa, 0xe7, 0xc2, 0xb6, 0x4f, 0x80, 0xaa, 0xb9, 0x05, 0x23, 0x01, 0x41, 0xf4,
-	0x5d, 0xe4, 0x75, 0x40, 0x86, 0x91, 0x2b, 0xa8, 0xa8, 0xbf, 0x81, 0x33, 0xbd, 0x97, 0xbc, 0x15,
-	0x79, 0xd5, 0xb7, 0x51, 0xbe, 0x23, 0x04, 0xa9, 0xa0, 0x82, 0x3e, 0x8c, 0x30, 0xb3, 0x55, 0x9d,
-	0x59, 0x47, 0x08, 0xc5, 0xac, 0x42, 0x74, 0x28, 0x8b, 0x7b, 0x70, 0x0a, 0x5e, 0xe6, 0x2e, 0xdf,
-	0xe3, 0x8e, 0x86, 0x56, 0xa8, 0xa9, 0x8e, 0xd9, 0x0f, 0x1c, 0xbd, 0x44, 0xc9, 0x52, 0x66, 0xfd,
-	0x7d, 0x03, 0x2d, 0xb6, 0x38, 0x0b, 0x24, 0x59, 0x47, 0x48, 0x1b, 0x83, 0x36, 0x8c, 0x02, 0xbc,
-	0x40, 0xae, 0x23, 0x33, 0xf1, 0xe9, 0xc4, 0x93, 0x3d, 0x10, 0xea, 0x9e, 0x3a, 0xe2, 0x42, 0xe2,
-	0x8f, 0x77, 0xc8, 0x55, 0x74, 0x39, 0x94, 0xfb, 0xd3, 0xfb, 0x40, 0x6d, 0x10, 0x03, 0x75, 0x6a,
-	0x18, 0x93, 0x6b, 0xe8, 0xca, 0x9c, 0xf0, 0x00, 0x44, 0xe0, 0x72, 0x86, 0x6f, 0x93, 0x6d, 0xb4,
-	0x35, 0xa7, 0xed, 0x53, 0xf1, 0x18, 0x04, 0x7e, 0xf1, 0xe9, 0xb7, 0xf3, 0x64, 0x0b, 0xe1, 0x50,
-	0xed, 0xb2, 0x53, 0x3e, 0xa4, 0x52, 0x8d, 0xf9, 0xe8, 0x7a, 0x7d, 0x8c, 0x96, 0xfb, 0x53, 0xf5,
-	0xe4, 0xd8, 0x40, 0x30, 0x5a, 0x8d, 0xed, 0xc1, 0x81, 0xeb, 0xe1, 0x05, 0xb5, 0x5c, 0x42, 0x8e,
-	0xfd, 0x00, 0x84, 0xec, 0x78, 0x30, 0x06, 0x26, 0x71, 0x2e, 0xa3, 0xb5, 0xc1, 0x03, 0x09, 0xb1,
-	0x56, 0x50, 0xfb, 0x9f, 0xd3, 0x5a, 0xe0, 0x79, 0x78, 0xb1, 0xfe, 0xfb, 0x1c, 0x5a, 0xea, 0x4f,
-	0xef, 0xba, 0xe0, 0xd9, 0xe4, 0x12, 0x5a, 0x89, 0xcc, 0x68, 0xb5, 0x4d, 0x84, 0x63, 0xa0, 0xc2,
-	0xd5, 0x67, 0x8f, 0x8d, 0x0b, 0x68, 0x03, 0xe7, 0x2e, 0xa0, 0x4d, 0x9c, 0x4f, 0x53, 0x75, 0x2b,
-	0xe9, 0x19, 0x0a, 0x17, 0xd0, 0x06, 0x5e, 0xbc, 0x80, 0x36, 0x71, 0x31, 0xac, 0x41, 0x48, 0x7b,
-	0xdd, 0xc1, 0x2d, 0xbc, 0x34, 0x47, 0x1a, 0x78, 0x79, 0x8e, 0x34, 0x71, 0x29, 0x3d, 0x57, 0xc7,
-	0x76, 0xf5, 0x6b, 0x8a, 0xd1, 0x05, 0xb4, 0x81, 0x57, 0xc8, 0x16, 0xda, 0x48, 0xd2, 0x9e, 0x8c,
-	0xb5, 0x11, 0xe0, 0xd5, 0x34, 0xde, 0xa7, 0xd3, 0x08, 0x9b, 0xf5, 0x3d, 0xb4, 0xdc, 0x03, 0x0f,
-	0x86, 0xf2, 0xd0, 0x57, 0xf3, 0xc5, 0xf6, 0xe0, 0x00, 0x26, 0x52, 0xd0, 0xa8, 0x6a, 0x09, 0xed,
-	0xb2, 0xa1, 0x37, 0xb1, 0x21, 0xac, 0x5a, 0x42, 0x3b, 0xd3, 0x90, 0xe6, 0xea, 0xa7, 0x68, 0x39,
-	0xfe, 0x05, 0xa2, 0xce, 0x28, 0xb6, 0x07, 0x07, 0x5c, 0xf6, 0x24, 0x15, 0x12, 0xec, 0x70, 0xc2,
-	0x44, 0x50, 0x97, 0xb5, 0xcb, 0x1c, 0x6c, 0x90, 0x0d, 0xb4, 0x96, 0xd0, 0x3b, 0x93, 0xe0, 0x0c,
-	0xe7, 0xc8, 0x65, 0x74, 0x29, 0x13, 0x08, 0x36, 0xce, 0x67, 0x60, 0xcb, 0xe3, 0x01, 0xd8, 0xf8,
-	0xd5, 0xba, 0x95, 0x7a, 0x1c, 0x08, 0x41, 0xeb, 0x89, 0x33, 0x38, 0xe0, 0x0c, 0xf0, 0x02, 0x79,
-	0x09, 0x6d, 0xcd, 0x98, 0x1e, 0x76, 0xc8, 0x94, 0x8d, 0x0d, 0x72, 0x05, 0x91, 0x99, 0xb4, 0x4f,
-	0x5d, 0x26, 0xa9, 0xcb, 0x70, 0xae, 0xfe, 0x36, 0x2a, 0x76, 0x18, 0x7d, 0xe4, 0x81, 0xda, 0x70,
-	0x68, 0x0d, 0xf6, 0xa8, 0xba, 0x8b, 0x0f, 0x47, 0x23, 0xbc, 0xa0, 0x36, 0x92, 0xa5, 0x0c, 0x1b,
-	0x29, 0xb8, 0x3b, 0x94, 0xee, 0x29, 0x1c, 0xb2, 0xb0, 0x97, 0xb2, 0x70, 0x34, 0xc2, 0xf9, 0xfa,
-	0xa7, 0x06, 0x2a, 0x1d, 0x0b, 0xaf, 0x37, 0x3c, 0x81, 0x31, 0xa8, 0xf4, 0x13, 0x67, 0xf6, 0x71,
-	0xcc, 0xd0, 0x31, 0x13, 0x30, 0xe4, 0x0e, 0x73, 0x9f, 0x80, 0x8d, 0x0d, 0x95, 0xe3, 0x4c, 0xbb,
-	0x2f, 0xa5, 0x8f, 0x73, 0x59, 0xd6, 0xa6, 0x92, 0xe2, 0x7c, 0x96, 0xdd, 0x75, 0x3d, 0xc0, 0x85,
-	0xec, 0x52, 0xbb, 0x63, 0x1f, 0x2f, 0x65, 0xc3, 0xba, 0xfe, 0x28, 0xc0, 0x1b, 0xf3, 0x8c, 0x05,
-	0x98, 0xa8, 0x4c, 0x66, 0x6c, 0x9f, 0x3a, 0x0c, 0x24, 0xbe, 0x9c, 0x9d, 0xf0, 0x9e, 0x2b, 0xf1,
-	0x66, 0xfd, 0x6f, 0x46, 0xfc, 0xcc, 0xa8, 0xab, 0x29, 0xb4, 0xa2, 0xb4, 0xb6, 0xd0, 0x46, 0xe4,
-	0x1f, 0x0a, 0x79, 0xc2, 0x8f, 0xdc, 0x29, 0x78, 0xd8, 0x98, 0xc7, 0xfb, 0x20, 0x41, 0x84, 0xb7,
-	0x40, 0x06, 0xbb, 0x9e, 0xe7, 0x8e, 0xb5, 0x96, 0x57, 0x87, 0x9a, 0xd6, 0x0e, 0x28, 0xe3, 0xa1,
-	0x54, 0x20, 0xdb, 0xc8, 0x8c, 0xa4, 0xfb, 0x30, 0xbd, 0x27, 0x5c, 0x3b, 0x35, 0x70, 0x91, 0xec,
-	0xa0, 0x1b, 0x91, 0xda, 0x17, 0xd4, 0x87, 0x27, 0xbc, 0xcd, 0x6d, 0x18, 0xd2, 0x13, 0xb0, 0x05,
-	0x67, 0xa9, 0xc8, 0x62, 0xfd, 0x67, 0x46, 0xe6, 0x71, 0x52, 0xa9, 0x26, 0x6e, 0x94, 0xcf, 0x36,
-	0x32, 0x67, 0xa8, 0x07, 0x43, 0x01, 0xf2, 0x0e, 0x9f, 0x0e, 0x0e, 0x68, 0xcb, 0xc3, 0xb6, 0xbe,
-	0x50, 0x13, 0x75, 0x37, 0x38, 0x1b, 0xef, 0x07, 0x4e, 0xa8, 0x41, 0x56, 0xeb, 0xb9, 0x0e, 0x73,
-	0x59, 0xa4, 0x8d, 0x48, 0x19, 0xbd, 0xf4, 0x59, 0xad, 0xd3, 0x6e, 0xbe, 0xf1, 0x46, 0xe3, 0x4b,
-	0xf8, 0xef, 0x46, 0xfd, 0xbf, 0x45, 0xb4, 0x14, 0xbd, 0x21, 0x6a, 0x53, 0x91, 0x39, 0x38, 0xe0,
-	0x1d, 0x21, 0xf0, 0x02, 0xb9, 0x8a, 0x48, 0x8c, 0x8e, 0x19, 0xa3, 0x63, 0xb0, 0x15, 0x7f, 0xa7,
-	0x46, 0x4c, 0x74, 0x39, 0x16, 0xba, 0x4c, 0x82, 0x60, 0xd4, 0x53, 0xca, 0x77, 0x6b, 0xe4, 0x1a,
-	0xda, 0x9a, 0x0d, 0x09, 0x26, 0xbe, 0xcf, 0xd5, 0xf7, 0x7a, 0xe8, 0xe3, 0xef, 0xcd, 0x69, 0xee,
-	0xd8, 0x0f, 0x2f, 0x62, 0xb0, 0xf1, 0xf7, 0x6b, 0x64, 0x13, 0x5d, 0x8a, 0xb5, 0xbe, 0x3b, 0x06,
-	0x3e, 0x91, 0xf8, 0x07, 0x35, 0xf2, 0x12, 0xda, 0x8c, 0x69, 0xef, 0x64, 0x22, 0xa5, 0xcb, 0x9c,
-	0x36, 0xff, 0x26, 0xc3, 0x3f, 0xcc, 0x48, 0x07, 0x5c, 0xb6, 0x38, 0x63, 0x30, 0x54, 0x73, 0xfd,
-	0xa8, 0x96, 0xde, 0xb6, 0x7a, 0xc1, 0xef, 0x52, 0xd7, 0x03, 0x1b, 0xbf, 0x9b, 0xd9, 0xb6, 0xfe,
-	0xd5, 0x1a, 0x29, 0xef, 0xd5, 0xc8, 0xcb, 0xe8, 0x4a, 0xb2, 0x10, 0x04, 0xea, 0xa9, 0x0a, 0x7f,
-	0x8e, 0xda, 0xf8, 0xc7, 0x35, 0xb2, 0x8d, 0xae, 0xc6, 0x62, 0xf4, 0xab, 0xf4, 0x80, 0xcb, 0xbb,
-	0x7c, 0xc2, 0x6c, 0xfc, 0x93, 0x4c, 0x56, 0x91, 0x1a, 0x5d, 0x28, 0x3f, 0xcd, 0xec, 0xe4, 0x0e,
-	0xb5, 0x23, 0x19, 0xff, 0x3c, 0x23, 0x74, 0xd9, 0x29, 0xf5, 0x5c, 0xfb, 0xd8, 0xea, 0xe2, 0x5f,
-	0xd4, 0xd4, 0x03, 0x98, 0x1a, 0xf1, 0x80, 0x7a, 0x13, 0xc0, 0xbf, 0xbc, 0x28, 0xbe, 0x4f, 0x1d,
-	0xfc, 0xab, 0xcc, 0xc6, 0x67, 0x42, 0xcf, 0x87, 0x21, 0x7e, 0x3f, 0x53, 0x23, 0xf5, 0x78, 0x24,
-	0xbb, 0xfe, 0x75, 0x26, 0xa7, 0x03, 0x2e, 0x4f, 0x5c, 0xe6, 0xf4, 0x79, 0x8b, 0x8f, 0xc7, 0xae,
-	0xc4, 0xbf, 0xc9, 0x0c, 0x0c, 0x61, 0x54, 0xa9, 0xdf, 0x66, 0x16, 0x3c, 0xf2, 0x28, 0x83, 0x59,
-	0x2d, 0x3e, 0xc8, 0xd4, 0x22, 0x14, 0xd5, 0xb8, 0x89, 0x00, 0xfc, 0xbb, 0x4c, 0xf1, 0x77, 0x7d,
-	0x3f, 0x19, 0xf5, 0x61, 0x46, 0xd9, 0xa7, 0xde, 0x88, 0x8b, 0x31, 0xd8, 0xfd, 0x29, 0xfe, 0x43,
-	0x8d, 0x5c, 0x41, 0x1b, 0xa9, 0x6a, 0xe8, 0xbb, 0x81, 0xe2, 0x3f, 0x66, 0x46, 0xa8, 0x2b, 0x2a,
-	0x5e, 0xe5, 0xa3, 0xcc, 0x88, 0xce, 0x54, 0x35, 0x9f, 0xea, 0xcb, 0x3f, 0x65, 0xf8, 0x51, 0x72,
-	0xf0, 0x7f, 0xce, 0x66, 0x0a, 0x9e, 0x97, 0x6c, 0xeb, 0x2f, 0x99, 0x45, 0x8e, 0x04, 0x3f, 0x75,
-	0x6d, 0x10, 0x6a, 0xb2, 0xbf, 0xd6, 0xc8, 0x2b, 0xe8, 0x5a, 0xac, 0x3c, 0x70, 0xb9, 0x47, 0x25,
-	0x04, 0xbb, 0xbe, 0x0f, 0xcc, 0x3e, 0x64, 0xde, 0x19, 0xfe, 0x77, 0x8d, 0xdc, 0x40, 0xaf, 0xcc,
-	0x4e, 0x25, 0x98, 0x8c, 0x46, 0xee, 0xd0, 0x05, 0x26, 0x8f, 0x40, 0x8c, 0x5d, 0xdd, 0x5d, 0x01,
-	0xfe, 0x4f, 0xad, 0xde, 0x46, 0xcb, 0xf1, 0xaf, 0x33, 0x75, 0x4f, 0xc6, 0xf6, 0xa0, 0x23, 0x04,
-	0x57, 0x9f, 0xdf, 0x86, 0xfe, 0x8b, 0x2c, 0x64, 0x5f, 0xa1, 0x42, 0x3d, 0x02, 0x69, 0xa4, 0xfe,
-	0x80, 0xc7, 0x85, 0x3b, 0x5f, 0x7f, 0xfa, 0xac, 0xbc, 0xf0, 0xc9, 0xb3, 0xf2, 0xc2, 0x8b, 0x67,
-	0x65, 0xe3, 0x5b, 0xe7, 0x65, 0xe3, 0x83, 0xf3, 0xb2, 0xf1, 0xf1, 0x79, 0xd9, 0x78, 0x7a, 0x5e,
-	0x36, 0xfe, 0x79, 0x5e, 0x36, 0xfe, 0x75, 0x5e, 0x5e, 0x78, 0x71, 0x5e, 0x36, 0xde, 0x7b, 0x5e,
-	0x5e, 0x78, 0xfa, 0xbc, 0xbc, 0xf0, 0xc9, 0xf3, 0xf2, 0xc2, 0xc3, 0x8a, 0xe3, 0xca, 0x93, 0xc9,
-	0xa3, 0x9b, 0x43, 0x3e, 0x7e, 0x9d, 0x8e, 0xfd, 0xd7, 0x6e, 0xdb, 0xfa, 0x9f, 0xc0, 0x7e, 0xfc,
-	0x9a, 0xc3, 0x95, 0xf9, 0x61, 0x2e, 0xbf, 0xbb, 0x7f, 0xf4, 0xa8, 0xa8, 0xff, 0xdf, 0xe0, 0xf6,
-	0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xbb, 0xd5, 0xc9, 0xcf, 0x48, 0x10, 0x00, 0x00,
+	// 2094 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x98, 0x4d, 0x6c, 0x24, 0x47,
+	0xd9, 0xc7, 0xdd, 0x33, 0xe3, 0xb1, 0xa7, 0xfc, 0xb1, 0xe5, 0xb2, 0xbd, 0xdb, 0x71, 0x9c, 0xd9,
+	0xd1, 0xbc, 0xc9, 0x3b, 0x66, 0x94, 0x38, 0xeb, 0x09, 0x91, 0xc0, 0x37, 0xaf, 0x3d, 0x9b, 0xb5,
+	0xe2, 0x2f, 0xf5, 0x8c, 0x17, 0xb2, 0x20, 0x46, 0xb5, 0xd3, 0xcf, 0xb4, 0x5b, 0xdb, 0x53, 0xd5,
+	0x54, 0xd7, 0x98, 0xf1, 0x9e, 0xb8, 0x20, 0x11, 0xbe, 0x12, 0x40, 0x8a, 0x38, 0xf0, 0x11, 0x59,
+	0x02, 0x42, 0x0e, 0x08, 0xee, 0x04, 0x24, 0x10, 0x52, 0xc4, 0xc5, 0x7b, 0x8c, 0x72, 0x62, 0xbd,
+	0x12, 0xe2, 0x00, 0xd2, 0x1e, 0xb8, 0x71, 0x41, 0x55, 0xfd, 0x31, 0xdd, 0x13, 0x9f, 0xa6, 0x9e,
+	0xdf, 0xff, 0xa9, 0xaa, 0xa7, 0x9e, 0xa7, 0x3e, 0x5a, 0x83, 0xe6, 0x68, 0xdf, 0x7f, 0x95, 0xf6,
+	0xfd, 0x75, 0x5f, 0x70, 0xc9, 0x49, 0x9e, 0xf6, 0xfd, 0x95, 0x8a, 0xc3, 0xb9, 0xe3, 0xc1, 0xab,
+	0x1a, 0x3d, 0x18, 0xf4, 0x5e, 0xb5, 0x21, 0xe8, 0x0a, 0xd7, 0x97, 0x5c, 0x84, 0x6e, 0xd5, 0x77,
+	0x72, 0xa8, 0xd8, 0x1e, 0xee, 0xb2, 0x1e, 0x27, 0x2f, 0xa1, 0x62, 0x4b, 0x52, 0x39, 0x08, 0xcc,
+	0x5c, 0xc5, 0x58, 0x9b, 0x6f, 0xcc, 0xad, 0xab, 0xd1, 0x0e, 0xfd, 0x10, 0x5a, 0x91, 0x48, 0x4c,
+	0x34, 0x75, 0xe8, 0x6f, 0xf3, 0x01, 0x93, 0x66, 0xa1, 0x62, 0xac, 0x15, 0xac, 0xd8, 0x24, 0x37,
+	0xd1, 0xcc, 0x1b, 0xc0, 0x20, 0x70, 0x83, 0xdd, 0x9d, 0xce, 0x2d, 0x73, 0xb2, 0x62, 0xac, 0xe5,
+	0x2d, 0x94, 0xa0, 0x5b, 0x59, 0x87, 0x0d, 0xb3, 0x58, 0x31, 0xd6, 0x8a, 0x29, 0x87, 0x8d, 0xac,
+	0x43, 0xc3, 0x9c, 0x1a, 0x73, 0x68, 0x28, 0x87, 0x6d, 0xce, 0x24, 0x0c, 0xa5, 0x9e, 0x02, 0x85,
+	0x53, 0x24, 0xe8, 0x56, 0xd6, 0x61, 0xc3, 0x9c, 0x09, 0x47, 0x48, 0xd0, 0x46, 0xd6, 0xa1, 0x61,
+	0xce, 0x8e, 0x39, 0x34, 0xaa, 0xff, 0x30, 0xd0, 0xe4, 0x1e, 0x77, 0x5c, 0x46, 0x56, 0x51, 0xe9,
+	0x38, 0x00, 0xb1, 0x47, 0x1f, 0x80, 0x67, 0x1a, 0x15, 0x63, 0xad, 0x64, 0x8d, 0x00, 0xa9, 0xa2,
+	0x29, 0x65, 0x1c, 0xef, 0xee, 0xe8, 0x7c, 0xcd, 0x34, 0xa6, 0x75, 0xbe, 0xda, 0xd4, 0xb1, 0x62,
+	0x41, 0x8d, 0xb0, 0x03, 0xa7, 0x6e, 0x17, 0x94, 0xd7, 0x64, 0x38, 0x42, 0x02, 0x48, 0x05, 0xcd,
+	0x84, 0x46, 0x38, 0x43, 0x51, 0xeb, 0x69, 0x44, 0x56, 0xd0, 0xf4, 0x5d, 0x1e, 0xc8, 0x2d, 0xdb,
+	0x16, 0xe6, 0xb4, 0x96, 0x13, 0x9b, 0x7c, 0x1e, 0xa1, 0xed, 0x13, 0xe8, 0x3e, 0xf4, 0xb9, 0xcb,
+	0xa4, 0x4e, 0xd5, 0x4c, 0x63, 0x49, 0x87, 0xa0, 0xa3, 0x1f, 0x69, 0x56, 0xca, 0x6f, 0xb3, 0x74,
+	0x7e, 0x61, 0x86, 0xcb, 0xab, 0x7e, 0x01, 0xcd, 0x47, 0x9e, 0xd4, 0xf3, 0x80, 0x39, 0x40, 0x08,
+	0x2a, 0xdc, 0xa5, 0xc1, 0x89, 0x5e, 0xeb, 0xac, 0xa5, 0xdb, 0x9b, 0xe4, 0xfc, 0xc2, 0x1c, 0xf3,
+	0xab, 0xde, 0x41, 0x73, 0x9a, 0x58, 0x10, 0xf8, 0x9c, 0x05, 0x40, 0xaa, 0x68, 0x56, 0x39, 0xc7,
+	0x76, 0x34, 0x40, 0x86, 0x6d, 0x2e, 0x9c, 0x5f, 0x98, 0xd9, 0x6e, 0xd5, 0x1e, 0xba, 0x36, 0x16,
+	0xab, 0xca, 0xd8, 0xd6, 0x40, 0x9e, 0xb4, 0xf9, 0x43, 0x60, 0x71, 0xce, 0x13, 0xa0, 0x32, 0xa6,
+	0x8c, 0xe6, 0xd0, 0x77, 0x05, 0x84, 0xfb, 0x34, 0x6f, 0xa5, 0xd1, 0xe6, 0xe2, 0xf9, 0x85, 0x39,
+	0x3e, 0x68, 0xf5, 0x27, 0x06, 0x42, 0x47, 0x6a, 0xde, 0xaf, 0x0f, 0x20, 0x90, 0xe4, 0xff, 0x51,
+	0xe9, 0xc8, 0x65, 0x6d, 0x2a, 0x1c, 0x90, 0x9f, 0xa9, 0xdd, 0x48, 0x22, 0x2f, 0xa2, 0xe9, 0x23,
+	0x97, 0x6d, 0x49, 0x29, 0x02, 0xb3, 0x50, 0xc9, 0x67, 0xdc, 0x12, 0x85, 0xbc, 0x8c, 0x4a, 0xea,
+	0x64, 0x40, 0xeb, 0x8c, 0x75, 0x75, 0x0d, 0xe7, 0x1b, 0xf3, 0xda, 0x2d, 0xa1, 0xd6, 0xc8, 0x61,
+	0x73, 0xfe, 0xfc, 0xc2, 0x4c, 0xc5, 0x52, 0x7d, 0x19, 0x95, 0xf6, 0xe8, 0x80, 0x75, 0x4f, 0x8e,
+	0xad, 0x3d, 0x82, 0x51, 0xfe, 0xd8, 0xda, 0x8b, 0x96, 0xad, 0x9a, 0x9b, 0x73, 0xe7, 0x17, 0xe6,
+	0xc8, 0xa1, 0xfa, 0xfb, 0x3c, 0xca, 0xb7, 0xa9, 0x43, 0x6e, 0xa0, 0xa9, 0x36, 0x75, 0xf4, 0x11,
+	0x08, 0x73, 0x50, 0xd4, 0xe6, 0xad, 0x91, 0xb0, 0x61, 0xe6, 0xf5, 0xce, 0x0e, 0x85, 0x8d, 0x91,
+	0xd0, 0x30, 0x0b, 0x29, 0xa1, 0xa1, 0x6a, 0xde, 0x86, 0x61, 0xb8, 0x81, 0x4a, 0x96, 0x6e, 0xab,
+	0x6d, 0x77, 0x28, 0x6c, 0x10, 0x2e, 0x73, 0xcc, 0x52, 0xc5, 0x58, 0xcb, 0x59, 0x89, 0x1d, 0xc7,
+	0x38, 0x97, 0xc4, 0xa8, 0x8a, 0xa2, 0x8f, 0x0f, 0x93, 0xed, 0x33, 0x1f, 0xcc, 0xf9, 0x70, 0x1b,
+	0xa7, 0x90, 0xda, 0x1e, 0x7b, 0x94, 0x39, 0x03, 0xea, 0xc0, 0x36, 0xb7, 0xc1, 0x24, 0x15, 0x63,
+	0x6d, 0xce, 0xca, 0x30, 0x52, 0x46, 0xc8, 0x02, 0xc7, 0xe5, 0x4c, 0x7b, 0x2c, 0x6a, 0x8f, 0x14,
+	0x21, 0xff, 0x87, 0x8a, 0xfb, 0x20, 0x85, 0xdb, 0x35, 0x57, 0x74, 0x8e, 0x67, 0x74, 0x8e, 0x43,
+	0x64, 0x45, 0x12, 0x59, 0x42, 0x93, 0x2d, 0xf7, 0x11, 0x7c, 0xd9, 0x7c, 0x5e, 0xdf, 0x4c, 0xa1,
+	0x11, 0xd3, 0xb7, 0xcc, 0xd5, 0x11, 0x7d, 0x2b, 0xa6, 0xf7, 0xcd, 0x17, 0x46, 0xf4, 0x3e, 0x59,
+	0x45, 0x85, 0x36, 0x75, 0x02, 0xb3, 0x32, 0x56, 0x6f, 0x4d, 0xc9, 0xe7, 0xd0, 0x74, 0x0b, 0x3c,
+	0xe8, 0xca, 0x43, 0xdf, 0x5c, 0x4b, 0x5d, 0x92, 0x31, 0xb4, 0x12, 0x79, 0x73, 0xea, 0xfc, 0xc2,
+	0x54, 0xb5, 0xaa, 0x7e, 0x05, 0x95, 0xb6, 0xc5, 0x99, 0x2f, 0xf9, 0x9b, 0x70, 0x46, 0x1a, 0x68,
+	0x26, 0x32, 0x5c, 0xb9, 0xbb, 0xa3, 0x2b, 0x3d, 0xdf, 0xc0, 0x7a, 0x8c, 0x14, 0xb7, 0xd2, 0x4e,
+	0xaa, 0x1a, 0x6f, 0xc2, 0xd9, 0xed, 0x33, 0x09, 0x81, 0xae, 0xdd, 0xac, 0x95, 0xd8, 0xd5, 0xf7,
+	0x0c, 0x34, 0x76, 0x3c, 0xba, 0x5d, 0x08, 0x82, 0xf4, 0xf1, 0x49, 0x23, 0x75, 0xbc, 0x74, 0x43,
+	0x57, 0x2a, 0x17, 0x1e, 0xaf, 0x04, 0xa8, 0x3a, 0x59, 0xd0, 0x13, 0x10, 0x44, 0xe7, 0x2f, 0xaf,
+	0x1d, 0x32, 0x8c, 0x5c, 0x47, 0x45, 0x7d, 0xd6, 0xce, 0x74, 0x2c, 0x79, 0x2b, 0xb2, 0xc2, 0x9d,
+	0x9a, 0x84, 0x52, 0x75, 0x50, 0xbe, 0x29, 0x04, 0xa9, 0xa0, 0x82, 0xae, 0x67, 0xb8, 0xd0, 0x59,
+	0xbd, 0xd0, 0xa6, 0x10, 0x8a, 0x59, 0x85, 0xa8, 0xae, 0x93, 0x7b, 0x70, 0x0a, 0x5e, 0xe6, 0xd1,
+	0xd9, 0xe3, 0x8e, 0x86, 0x56, 0xa8, 0xa9, 0x4d, 0xb7, 0x1f, 0x38, 0x7a, 0xc6, 0x92, 0xa5, 0x9a,
+	0x61, 0x7a, 0x9b, 0x42, 0xd4, 0xdf, 0x37, 0xd0, 0xe4, 0x36, 0x67, 0x81, 0x24, 0xf3, 0x08, 0xe9,
+	0x46, 0x67, 0x07, 0x7a, 0x01, 0x9e, 0x20, 0x2f, 0x20, 0x33, 0xb1, 0xe9, 0xc0, 0x93, 0x2d, 0x10,
+	0xea, 0x66, 0x3d, 0xe2, 0x42, 0xe2, 0x8f, 0xd7, 0xc8, 0x0d, 0xb4, 0x18, 0xca, 0xed, 0xe1, 0x5d,
+	0xa0, 0x36, 0x88, 0x8e, 0xda, 0x01, 0x18, 0x93, 0x15, 0x74, 0x7d, 0x4c, 0xb8, 0x07, 0x22, 0x70,
+	0x39, 0xc3, 0xaf, 0x91, 0x55, 0xb4, 0x3c, 0xa6, 0xed, 0x53, 0xf1, 0x10, 0x04, 0x7e, 0xf6, 0xe9,
+	0xb7, 0xf2, 0x64, 0x19, 0xe1, 0x50, 0xdd, 0x65, 0xa7, 0xbc, 0x4b, 0xa5, 0xea, 0xf3, 0xd1, 0x0b,
+	0xf5, 0x3e, 0x9a, 0x6e, 0x0f, 0xd5, 0x23, 0x69, 0x03, 0xc1, 0x68, 0x36, 0x6e, 0x77, 0x0e, 0x5c,
+	0x0f, 0x4f, 0xa8, 0xe9, 0x12, 0x72, 0xec, 0x07, 0x20, 0x64, 0xd3, 0x83, 0x3e, 0x30, 0x89, 0x73,
+	0x19, 0x6d, 0x07, 0x3c, 0x90, 0x10, 0x6b, 0x05, 0x15, 0xff, 0x98, 0xb6, 0x0d, 0x9e, 0x87, 0x27,
+	0xeb, 0xbf, 0xcd, 0xa1, 0xa9, 0xf6, 0xf0, 0x8e, 0x0b, 0x9e, 0x4d, 0xae, 0xa1, 0x99, 0xa8, 0x19,
+	0xcd, 0xb6, 0x84, 0x70, 0x0c, 0x94, 0xbb, 0xba, 0x42, 0xb0, 0x71, 0x05, 0xdd, 0xc0, 0xb9, 0x2b,
+	0x68, 0x03, 0xe7, 0xd3, 0x54, 0x5d, 0x80, 0x7a, 0x84, 0xc2, 0x15, 0x74, 0x03, 0x4f, 0x5e, 0x41,
+	0x1b, 0xb8, 0x18, 0xe6, 0x20, 0xa4, 0xad, 0xdd, 0xce, 0x2d, 0x3c, 0x35, 0x46, 0x36, 0xf0, 0xf4,
+	0x18, 0x69, 0xe0, 0x52, 0x7a, 0xac, 0xa6, 0xed, 0xea, 0xf7, 0x1f, 0xa3, 0x2b, 0xe8, 0x06, 0x9e,
+	0x21, 0xcb, 0x68, 0x21, 0x59, 0xf6, 0xa0, 0xaf, 0x1b, 0x01, 0x9e, 0x4d, 0xe3, 0x7d, 0x3a, 0x8c,
+	0xb0, 0x59, 0xdf, 0x1b, 0x9d, 0x6a, 0x35, 0x5e, 0xdc, 0xee, 0x1c, 0xc0, 0x40, 0x0a, 0x1a, 0x65,
+	0x2d, 0xa1, 0xbb, 0xac, 0xeb, 0x0d, 0x6c, 0xc0, 0x46, 0x86, 0x36, 0x87, 0x21, 0xcd, 0xd5, 0x4f,
+	0xd1, 0x74, 0xfc, 0xcd, 0xa4, 0x6a, 0x14, 0xb7, 0x3b, 0x07, 0x5c, 0xb6, 0x24, 0x15, 0x12, 0xec,
+	0x70, 0xc0, 0x44, 0x50, 0xef, 0x82, 0xcb, 0x1c, 0x6c, 0x90, 0x05, 0x34, 0x97, 0xd0, 0xdb, 0x83,
+	0xe0, 0x0c, 0xe7, 0xc8, 0x22, 0xba, 0x96, 0x71, 0x04, 0x1b, 0xe7, 0x33, 0x70, 0xdb, 0xe3, 0x01,
+	0xd8, 0xf8, 0xa5, 0xba, 0x95, 0x7a, 0x87, 0x08, 0x41, 0xf3, 0x89, 0xd1, 0x39, 0xe0, 0x0c, 0xf0,
+	0x04, 0x79, 0x0e, 0x2d, 0x8f, 0x98, 0xee, 0x76, 0xc8, 0x54, 0x1b, 0x1b, 0xe4, 0x3a, 0x22, 0x23,
+	0x69, 0x9f, 0xba, 0x4c, 0x52, 0x97, 0xe1, 0x5c, 0xfd, 0x6b, 0xa8, 0xd8, 0x64, 0xf4, 0x81, 0x07,
+	0x2a, 0xe0, 0xb0, 0xd5, 0xd9, 0xa3, 0xea, 0x5e, 0x3f, 0xec, 0xf5, 0xf0, 0x84, 0x0a, 0x24, 0x4b,
+	0x19, 0x36, 0x52, 0x70, 0xab, 0x2b, 0xdd, 0x53, 0x38, 0x64, 0xe1, 0x5e, 0xca, 0xc2, 0x5e, 0x0f,
+	0xe7, 0xeb, 0x9f, 0x1a, 0xa8, 0x74, 0x2c, 0xbc, 0x56, 0xf7, 0x04, 0xfa, 0xa0, 0x96, 0x9f, 0x18,
+	0xa3, 0xc3, 0x31, 0x42, 0xc7, 0x4c, 0x40, 0x97, 0x3b, 0xcc, 0x7d, 0x04, 0x36, 0x36, 0xd4, 0x1a,
+	0x47, 0xda, 0x5d, 0x29, 0x7d, 0x9c, 0xcb, 0xb2, 0x1d, 0x2a, 0x29, 0xce, 0x67, 0xd9, 0x1d, 0xd7,
+	0x03, 0x5c, 0xc8, 0x4e, 0xb5, 0xd5, 0xf7, 0xf1, 0x54, 0xd6, 0x6d, 0xd7, 0xef, 0x05, 0x78, 0x61,
+	0x9c, 0xb1, 0x00, 0x13, 0xb5, 0x92, 0x11, 0xdb, 0xa7, 0x0e, 0x03, 0x89, 0x17, 0xb3, 0x03, 0xbe,
+	0xe1, 0x4a, 0xbc, 0x54, 0xff, 0xab, 0x11, 0x3f, 0x59, 0xea, 0x6a, 0x0a, 0x5b, 0xd1, 0xb2, 0x96,
+	0xd1, 0x42, 0x64, 0x1f, 0x0a, 0x79, 0xc2, 0x8f, 0xdc, 0x21, 0x78, 0xd8, 0x18, 0xc7, 0xfb, 0x20,
+	0x41, 0x84, 0xb7, 0x40, 0x06, 0xbb, 0x9e, 0xe7, 0xf6, 0xb5, 0x96, 0x57, 0x45, 0x4d, 0x6b, 0x07,
+	0x94, 0xf1, 0x50, 0x2a, 0x90, 0x55, 0x64, 0x46, 0xd2, 0x5d, 0x18, 0xbe, 0x21, 0x5c, 0x3b, 0xd5,
+	0x71, 0x92, 0xac, 0xa1, 0x17, 0x23, 0xb5, 0x2d, 0xa8, 0x0f, 0x8f, 0xf8, 0x0e, 0xb7, 0xa1, 0x4b,
+	0x4f, 0xc0, 0x16, 0x9c, 0xa5, 0x3c, 0x8b, 0xf5, 0xf7, 0x8c, 0xcc, 0xa3, 0xa5, 0x96, 0x9a, 0x98,
+	0xd1, 0x7a, 0x56, 0x91, 0x39, 0x42, 0x2d, 0xe8, 0x0a, 0x90, 0xb7, 0xf9, 0xb0, 0x73, 0x40, 0xb7,
+	0x3d, 0x6c, 0xeb, 0x0b, 0x35, 0x51, 0xb7, 0x82, 0xb3, 0xfe, 0x7e, 0xe0, 0x84, 0x1a, 0x64, 0xb5,
+	0x96, 0xeb, 0x30, 0x97, 0x45, 0x5a, 0x8f, 0x94, 0xd1, 0x73, 0x9f, 0xd5, 0x9a, 0x3b, 0x8d, 0xd7,
+	0x5f, 0xdf, 0xf8, 0x22, 0xfe, 0x9b, 0x51, 0xff, 0x6f, 0x11, 0x4d, 0x45, 0x8f, 0x89, 0x0a, 0x2a,
+	0x6a, 0x76, 0x0e, 0x78, 0x53, 0x08, 0x3c, 0x41, 0x6e, 0x20, 0x12, 0xa3, 0x63, 0xc6, 0x68, 0x1f,
+	0x6c, 0xc5, 0xbf, 0x5d, 0x23, 0x26, 0x5a, 0x8c, 0x85, 0x5d, 0x26, 0x41, 0x30, 0xea, 0x29, 0xe5,
+	0xed, 0x1a, 0x59, 0x41, 0xcb, 0xa3, 0x2e, 0xc1, 0xc0, 0xf7, 0xb9, 0x3a, 0xaf, 0x87, 0x3e, 0xfe,
+	0xce, 0x98, 0xe6, 0xf6, 0xfd, 0xf0, 0x22, 0x06, 0x1b, 0x7f, 0xb7, 0x46, 0x96, 0xd0, 0xb5, 0x58,
+	0x6b, 0xbb, 0x7d, 0xe0, 0x03, 0x89, 0xbf, 0x57, 0x23, 0xcf, 0xa1, 0xa5, 0x98, 0xb6, 0x4e, 0x06,
+	0x52, 0xba, 0xcc, 0xd9, 0xe1, 0xdf, 0x60, 0xf8, 0xfb, 0x19, 0xe9, 0x80, 0xcb, 0x6d, 0xce, 0x18,
+	0x74, 0xd5, 0x58, 0x3f, 0xa8, 0xa5, 0xc3, 0x56, 0xcf, 0xe9, 0x1d, 0xea, 0x7a, 0x60, 0xe3, 0x77,
+	0x32, 0x61, 0xeb, 0x0f, 0xdc, 0x48, 0x79, 0xb7, 0x46, 0x9e, 0x47, 0xd7, 0x93, 0x89, 0x20, 0x50,
+	0x4f, 0x55, 0xf8, 0x39, 0x6c, 0xe3, 0x1f, 0xd6, 0xc8, 0x2a, 0xba, 0x11, 0x8b, 0xd1, 0x47, 0xe7,
+	0x01, 0x97, 0x77, 0xf8, 0x80, 0xd9, 0xf8, 0x47, 0x99, 0x55, 0x45, 0x6a, 0x74, 0xa1, 0xfc, 0x38,
+	0x13, 0xc9, 0x6d, 0x6a, 0x47, 0x32, 0xfe, 0x69, 0x46, 0xd8, 0x65, 0xa7, 0xd4, 0x73, 0xed, 0x63,
+	0x6b, 0x17, 0xff, 0xac, 0xa6, 0x1e, 0xc0, 0x54, 0x8f, 0x7b, 0xd4, 0x1b, 0x00, 0xfe, 0xf9, 0x55,
+	0xfe, 0x6d, 0xea, 0xe0, 0x5f, 0x64, 0x02, 0x1f, 0x09, 0x2d, 0x1f, 0xba, 0xf8, 0xfd, 0x4c, 0x8e,
+	0xd4, 0xe3, 0x91, 0x44, 0xfd, 0xcb, 0xcc, 0x9a, 0x0e, 0xb8, 0x3c, 0x71, 0x99, 0xd3, 0xe6, 0xdb,
+	0xbc, 0xdf, 0x77, 0x25, 0xfe, 0x55, 0xa6, 0x63, 0x08, 0xa3, 0x4c, 0xfd, 0x3a, 0x33, 0xe1, 0x91,
+	0x47, 0x19, 0x8c, 0x72, 0xf1, 0x41, 0x26, 0x17, 0xa1, 0xa8, 0xfa, 0x0d, 0x04, 0xe0, 0xdf, 0x64,
+	0x92, 0xbf, 0xe5, 0xfb, 0x49, 0xaf, 0x0f, 0x33, 0xca, 0x3e, 0xf5, 0x7a, 0x5c, 0xf4, 0xc1, 0x6e,
+	0x0f, 0xf1, 0xef, 0x6a, 0xe4, 0x3a, 0x5a, 0x48, 0x65, 0x43, 0xdf, 0x0d, 0x14, 0xff, 0x21, 0xd3,
+	0x43, 0x5d, 0x51, 0xf1, 0x2c, 0x1f, 0x65, 0x7a, 0x34, 0x87, 0x6a, 0xf3, 0xa9, 0x7d, 0xf9, 0xc7,
+	0x0c, 0x3f, 0x4a, 0x0a, 0xff, 0xa7, 0xec, 0x4a, 0xc1, 0xf3, 0x92, 0xb0, 0xfe, 0x9c, 0x99, 0xe4,
+	0x48, 0xf0, 0x53, 0xd7, 0x06, 0xa1, 0x06, 0xfb, 0x4b, 0x8d, 0xdc, 0x44, 0x2b, 0xb1, 0x72, 0xcf,
+	0xe5, 0x1e, 0x95, 0x10, 0x6c, 0xf9, 0x3e, 0x30, 0xfb, 0x90, 0x79, 0x67, 0xf8, 0x5f, 0x35, 0xf2,
+	0x22, 0xba, 0x39, 0xaa, 0x4a, 0x30, 0xe8, 0xf5, 0xdc, 0xae, 0x0b, 0x4c, 0x1e, 0x81, 0xe8, 0xbb,
+	0x7a, 0x77, 0x05, 0xf8, 0xdf, 0xb5, 0xfa, 0x0e, 0x9a, 0x8e, 0x3f, 0xd3, 0xd4, 0x3d, 0x19, 0xb7,
+	0x3b, 0x4d, 0x21, 0xb8, 0x3a, 0x7e, 0x0b, 0x68, 0x2e, 0x61, 0x5f, 0xa2, 0x42, 0x3d, 0x02, 0x69,
+	0xa4, 0xfe, 0x72, 0xc0, 0x85, 0xcd, 0xd7, 0x51, 0x81, 0x4a, 0x29, 0xc8, 0xcd, 0xf5, 0xf0, 0xaf,
+	0x8a, 0xf5, 0xf8, 0xaf, 0x8a, 0xf5, 0x7d, 0x08, 0x02, 0xea, 0xc0, 0xa1, 0xaf, 0x3e, 0xa3, 0x02,
+	0xf3, 0x3f, 0x6f, 0x87, 0x5f, 0xa3, 0xda, 0xfd, 0xf6, 0x57, 0x1f, 0x3f, 0x29, 0x4f, 0x7c, 0xf2,
+	0xa4, 0x3c, 0xf1, 0xec, 0x49, 0xd9, 0xf8, 0xe6, 0x65, 0xd9, 0xf8, 0xe0, 0xb2, 0x6c, 0x7c, 0x7c,
+	0x59, 0x36, 0x1e, 0x5f, 0x96, 0x8d, 0xbf, 0x5f, 0x96, 0x8d, 0x7f, 0x5e, 0x96, 0x27, 0x9e, 0x5d,
+	0x96, 0x8d, 0x77, 0x9f, 0x96, 0x27, 0x1e, 0x3f, 0x2d, 0x4f, 0x7c, 0xf2, 0xb4, 0x3c, 0x71, 0xbf,
+	0xe2, 0xb8, 0xf2, 0x64, 0xf0, 0x60, 0xbd, 0xcb, 0xfb, 0xea, 0x9f, 0x92, 0x57, 0x5e, 0xb3, 0xf5,
+	0x4f, 0x60, 0x3f, 0x7c, 0xc5, 0xe1, 0xaa, 0xf9, 0x61, 0x2e, 0xbf, 0xb5, 0x7f, 0xf4, 0xa0, 0xa8,
+	0x83, 0x78, 0xed, 0x7f, 0x03, 0x00, 0x5b, 0x06, 0x69, 0xc9, 0x53, 0x11, 0x00, 0x00,
 }
 
 func (x Const) String() string {
@@ -1846,6 +1875,9 @@ func (this *Tag) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.SelectOp != that1.SelectOp {
+		return false
+	}
 	return true
 }
 func (this *CryptoKey) Equal(that interface{}) bool {
@@ -2035,7 +2067,7 @@ func (this *Tag) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 18)
+	s := make([]string, 0, 19)
 	s = append(s, "&amp.Tag{")
 	s = append(s, "TagID_0: "+fmt.Sprintf("%#v", this.TagID_0)+",\n")
 	s = append(s, "TagID_1: "+fmt.Sprintf("%#v", this.TagID_1)+",\n")
@@ -2053,6 +2085,7 @@ func (this *Tag) GoString() string {
 	if this.Tags != nil {
 		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
 	}
+	s = append(s, "SelectOp: "+fmt.Sprintf("%#v", this.SelectOp)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2441,6 +2474,13 @@ func (m *Tag) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SelectOp != 0 {
+		i = encodeVarintAmp(dAtA, i, uint64(m.SelectOp))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
 	if len(m.Tags) > 0 {
 		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2878,6 +2918,9 @@ func (m *Tag) Size() (n int) {
 			n += 2 + l + sovAmp(uint64(l))
 		}
 	}
+	if m.SelectOp != 0 {
+		n += 2 + sovAmp(uint64(m.SelectOp))
+	}
 	return n
 }
 
@@ -3060,6 +3103,7 @@ func (this *Tag) String() string {
 		`SizeY:` + fmt.Sprintf("%v", this.SizeY) + `,`,
 		`SizeZ:` + fmt.Sprintf("%v", this.SizeZ) + `,`,
 		`Tags:` + repeatedStringForTags + `,`,
+		`SelectOp:` + fmt.Sprintf("%v", this.SelectOp) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4337,6 +4381,25 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 40:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectOp", wireType)
+			}
+			m.SelectOp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAmp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelectOp |= SelectOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAmp(dAtA[iNdEx:])
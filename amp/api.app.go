@@ -47,6 +47,14 @@ type AppContext interface {
 	PutAppAttr(attrSpec tag.ID, src tag.Value) error
 }
 
+// AppAttrWatcher is optionally implemented by an AppInstance to be notified when one of its app attrs is put
+// (see AppContext.PutAppAttr), such as when a user edits the app's settings from another session.
+type AppAttrWatcher interface {
+
+	// Called from the AppInstance's task context with the attr spec ID of the attr that was put.
+	OnAppAttrChanged(attrSpec tag.ID)
+}
+
 // Pinner is characterized by the ability to emit Pins.
 type Pinner interface {
 
@@ -127,6 +135,29 @@ type TxOp struct {
 	DataOfs uint64   // offset into TxMsg.DataStore
 }
 
+// CellStore is an in-memory model of cell state, built by applying the ops of successive TxMsgs -- concurrency safe.
+//
+// Elements are keyed by (CellID, AttrID, SI) and the latest edit of an element (see CompareEdits) wins, where a
+// deletion wins over an upsert having the same EditID, so txs may be applied in any order.  Deletions are retained
+// as tombstones so that a late arriving edit older than (or as old as) a deletion is ignored.
+type CellStore interface {
+
+	// Applies the TxOpCode_UpsertElement, TxOpCode_DeleteElement, and TxOpCode_DeleteCell ops of the given tx.
+	// tx is validated first (see TxMsg.Validate) and is not retained.
+	Apply(tx *TxMsg) error
+
+	// Unmarshals the current value of the given element into dst, returning ErrPropertyNotFound if absent.
+	Load(cellID, attrID, SI tag.ID, dst tag.Value) error
+
+	// Calls fn in SI order for each element of the given cell attribute where minSI <= SI < maxSI, stopping if fn returns false.
+	// If maxSI is nil, there is no upper bound.  op.DataOfs is 0 and op.DataLen is len(data), which fn must not retain.
+	Scan(cellID, attrID, minSI, maxSI tag.ID, fn func(op *TxOp, data []byte) bool)
+
+	// Appends the current state of the given cells (or all cells if none are given) to dst, typically to be pushed
+	// to a newly pinned Requester.  Ops are appended in (CellID, AttrID, SI) order for each cell.
+	Snapshot(dst *TxMsg, cellIDs ...tag.ID)
+}
+
 type AttrDef struct {
 	tag.Spec
 	Prototype tag.Value
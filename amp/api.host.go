@@ -40,6 +40,15 @@ type Transport interface {
 	RecvTx() (*TxMsg, error)
 }
 
+// AuthenticatedTransport is optionally implemented by a Transport that authenticates the remote device (e.g. via a TLS client certificate).
+type AuthenticatedTransport interface {
+	Transport
+
+	// Returns the ID of the authenticated remote device, or "" if the device did not authenticate.
+	// When non-empty, a Host uses this as the session's Login.DeviceUID, superseding any DeviceUID sent by the client.
+	DeviceUID() string
+}
+
 // HostService attaches to a amp.Host as a child, extending host functionality.
 type HostService interface {
 	task.Context
@@ -101,6 +110,9 @@ type Registry interface {
 
 	// Instantiates an attr element value for a given attr spec -- typically followed by tag.Value.Unmarshal()
 	MakeValue(attrSpec tag.ID) (tag.Value, error)
+
+	// Looks-up the AttrDef registered for a given attr spec, such as to resolve an attr ID to its canonic spec -- READ ONLY ACCESS
+	GetAttrDef(attrSpec tag.ID) (AttrDef, error)
 }
 
 // Requester wraps a client request to receive a cell's state / updates.
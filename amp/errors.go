@@ -1,6 +1,7 @@
 package amp
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -78,7 +79,8 @@ func GetErrCode(err error) ErrCode {
 		return ErrCode_NoErr
 	}
 
-	if arcErr, ok := err.(*Err); ok {
+	var arcErr *Err
+	if errors.As(err, &arcErr) {
 		return arcErr.Code
 	}
 
@@ -0,0 +1,61 @@
+// Package host is a lightweight, in-process reference implementation of amp.Host.
+//
+// It binds an amp.Transport to a Session, routes client pin requests to AppInstance.ServeRequest(), and manages
+// the lifecycle of app instances and pins over a task.Context tree.  This allows an amp.App to be run and tested
+// end-to-end using nothing but this module.
+package host
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/login"
+	"github.com/amp-3d/amp-sdk-go/amp/registry"
+	"github.com/amp-3d/amp-sdk-go/amp/settings"
+	"github.com/amp-3d/amp-sdk-go/stdlib/media"
+)
+
+// Opts specifies how a Host is started.
+type Opts struct {
+	Label       string          // logging label for the Host's task.Context
+	Registry    amp.Registry    // Host registry -- if nil, registry.Global() is used
+	AppDataPath string          // root dir of each AppContext.LocalDataPath()
+	Publisher   media.Publisher // returned by Session.AssetPublisher() -- if nil, publishing assets is unsupported
+	DebugMode   bool            // passed to task.Info.DebugMode
+	ValidateTxs bool            // if set, Session.SendTx() checks each tx via TxMsg.Validate() (useful when developing an app)
+
+	// If set, each session must complete the login exchange (see package login) before making requests,
+	// where the client's challenge response is checked by this Verifier.
+	// If nil, a client Login is accepted as given.
+	LoginVerifier login.Verifier
+
+	// Number of failed login responses after which a session is closed -- if <= 0, DefaultMaxLoginFailures is used.
+	MaxLoginFailures int
+
+	// Issues and resumes login checkpoints -- if nil, login.NewCheckpoints() is used with a random key.
+	Checkpoints login.Checkpoints
+
+	// Backs AppContext.GetAppAttr() and PutAppAttr() for all sessions -- if nil, a settings.NewFileBackend()
+	// store rooted at AppDataPath is used.
+	Settings settings.Store
+}
+
+// DefaultMaxLoginFailures is the default Opts.MaxLoginFailures.
+const DefaultMaxLoginFailures = 3
+
+// DefaultOpts returns the suggested Opts for a Host.
+func DefaultOpts() Opts {
+	return Opts{
+		Label:       "amp.Host",
+		Registry:    registry.Global(),
+		AppDataPath: filepath.Join(os.TempDir(), "amp-host"),
+
+		MaxLoginFailures: DefaultMaxLoginFailures,
+	}
+}
+
+// StartNewHost starts a new amp.Host with the given options.
+func StartNewHost(opts Opts) (amp.Host, error) {
+	return startNewHost(opts)
+}
@@ -0,0 +1,144 @@
+package host
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/settings"
+	"github.com/amp-3d/amp-sdk-go/stdlib/media"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+// appContext implements amp.AppContext
+type appContext struct {
+	task.Context
+	sess     *session
+	app      *amp.App
+	instance amp.AppInstance
+
+	watchMu   sync.Mutex
+	watcher   amp.AppAttrWatcher // if set, notified of changes to the attrs of the app's current scope
+	unwatch   func()             // cancels the watch of the current scope
+	unwatched bool               // set once the app is closing
+}
+
+// startAppContext starts a child context of the given session and invokes app.NewAppInstance() within it.
+func startAppContext(sess *session, app *amp.App) (*appContext, error) {
+	ctx := &appContext{
+		sess: sess,
+		app:  app,
+	}
+
+	if err := utils.EnsureDirAndMaxPerms(ctx.LocalDataPath(), utils.DefaultDirPerms); err != nil {
+		return nil, err
+	}
+
+	var err error
+	ctx.Context, err = sess.StartChild(&task.Task{
+		Info: task.Info{
+			Label:     "app: " + app.AppSpec.Canonic,
+			DebugMode: sess.Info().DebugMode,
+		},
+		OnClosing: func() {
+			if inst := sess.onAppClosing(ctx); inst != nil {
+				inst.OnClosing()
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inst, err := app.NewAppInstance(ctx)
+	if err != nil {
+		ctx.Close()
+		return nil, err
+	}
+
+	// Notify the instance of settings changes until it closes
+	if watcher, ok := inst.(amp.AppAttrWatcher); ok {
+		ctx.watcher = watcher
+		ctx.watchScope()
+		_, err = ctx.Go("settings watch", func(watchCtx task.Context) {
+			<-watchCtx.Closing()
+			ctx.stopWatch()
+		})
+		if err != nil {
+			ctx.stopWatch()
+		}
+	}
+
+	sess.mu.Lock()
+	ctx.instance = inst
+	sess.mu.Unlock()
+	return ctx, nil
+}
+
+// Implements amp.AppContext
+func (ctx *appContext) Session() amp.Session {
+	return ctx.sess
+}
+
+// Implements amp.AppContext
+func (ctx *appContext) LocalDataPath() string {
+	return filepath.Join(ctx.sess.host.opts.AppDataPath, ctx.app.AppSpec.Canonic)
+}
+
+// Implements amp.AppContext
+func (ctx *appContext) PublishAsset(asset media.Asset, opts media.PublishOpts) (URL string, err error) {
+	return ctx.sess.AssetPublisher().PublishAsset(asset, opts)
+}
+
+// Implements amp.AppContext
+func (ctx *appContext) GetAppAttr(attrSpec tag.ID, dst tag.Value) error {
+	return ctx.sess.host.opts.Settings.Get(ctx.scope(), attrSpec, dst)
+}
+
+// Implements amp.AppContext
+func (ctx *appContext) PutAppAttr(attrSpec tag.ID, src tag.Value) error {
+	return ctx.sess.host.opts.Settings.Put(ctx.scope(), attrSpec, src)
+}
+
+// scope returns the scope of GetAppAttr() and PutAppAttr(), which follows the session's current login.
+func (ctx *appContext) scope() settings.Scope {
+	login := ctx.sess.LoginInfo()
+	return settings.ScopeOf(ctx.app, &login, ctx.sess.sessionID)
+}
+
+// watchScope (re)starts notifying ctx.watcher of changes to the app's current scope, such as after a login.
+func (ctx *appContext) watchScope() {
+	if ctx.watcher == nil {
+		return
+	}
+	scope := ctx.scope()
+
+	ctx.watchMu.Lock()
+	defer ctx.watchMu.Unlock()
+
+	if ctx.unwatched {
+		return
+	}
+	if ctx.unwatch != nil {
+		ctx.unwatch()
+	}
+	ctx.unwatch = ctx.sess.host.opts.Settings.Watch(scope, func(attrSpec tag.ID) {
+		ctx.Go("OnAppAttrChanged", func(task.Context) {
+			ctx.watcher.OnAppAttrChanged(attrSpec)
+		})
+	})
+}
+
+// stopWatch stops notifying ctx.watcher of changes.
+func (ctx *appContext) stopWatch() {
+	ctx.watchMu.Lock()
+	defer ctx.watchMu.Unlock()
+
+	ctx.unwatched = true
+	if ctx.unwatch != nil {
+		ctx.unwatch()
+		ctx.unwatch = nil
+	}
+}
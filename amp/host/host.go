@@ -0,0 +1,71 @@
+package host
+
+import (
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/login"
+	"github.com/amp-3d/amp-sdk-go/amp/registry"
+	"github.com/amp-3d/amp-sdk-go/amp/settings"
+	"github.com/amp-3d/amp-sdk-go/stdlib/media"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+)
+
+// host implements amp.Host
+type host struct {
+	task.Context
+	opts Opts
+}
+
+func startNewHost(opts Opts) (*host, error) {
+	if opts.Registry == nil {
+		opts.Registry = registry.Global()
+	}
+	if opts.Label == "" {
+		opts.Label = "amp.Host"
+	}
+	if opts.Publisher == nil {
+		opts.Publisher = noPublisher{}
+	}
+	if opts.Checkpoints == nil {
+		var err error
+		if opts.Checkpoints, err = login.NewCheckpoints(nil, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Settings == nil {
+		opts.Settings = settings.NewStore(settings.NewFileBackend(opts.AppDataPath))
+	}
+
+	h := &host{
+		opts: opts,
+	}
+
+	var err error
+	h.Context, err = task.Start(&task.Task{
+		Info: task.Info{
+			Label:     opts.Label,
+			DebugMode: opts.DebugMode,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Implements amp.Host
+func (h *host) HostRegistry() amp.Registry {
+	return h.opts.Registry
+}
+
+// Implements amp.Host
+func (h *host) StartNewSession(parent amp.HostService, via amp.Transport) (amp.Session, error) {
+	return startNewSession(h, parent, via)
+}
+
+// noPublisher is used when Opts.Publisher is not given.
+type noPublisher struct{}
+
+func (noPublisher) PublishAsset(asset media.Asset, opts media.PublishOpts) (URL string, err error) {
+	return "", amp.ErrCode_Unimplemented.Error("host: asset publishing not available")
+}
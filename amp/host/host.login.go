@@ -0,0 +1,112 @@
+package host
+
+import (
+	"crypto/rand"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// loginState tracks a session's progress through the login exchange (see package login).
+type loginState struct {
+	loginID   tag.ID     // GenesisID of the client's Login tx
+	pending   *amp.Login // login awaiting a challenge response
+	challenge []byte     // challenge issued for the pending login
+}
+
+// onLogin handles a client Login (step 1), superseding any previous login once it completes.
+func (sess *session) onLogin(loginID tag.ID, login *amp.Login) error {
+	if sess.deviceUID != "" {
+		login.DeviceUID = sess.deviceUID
+	}
+
+	// Only a pending challenge is discarded -- the current login remains in effect until the new one completes.
+	sess.auth = loginState{}
+
+	// A login is accepted as given when there is no verifier or it resumes from a valid checkpoint.
+	opts := &sess.host.opts
+	if opts.LoginVerifier == nil || opts.Checkpoints.Resume(login) == nil {
+		return sess.completeLogin(loginID, login)
+	}
+
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return err
+	}
+	sess.auth = loginState{
+		loginID:   loginID,
+		pending:   login,
+		challenge: challenge,
+	}
+	return sess.sendLoginReply(loginID, &amp.LoginChallenge{
+		Hash: challenge,
+	}, amp.OpStatus_Syncing)
+}
+
+// onLoginResponse handles a client's response (step 3) to the challenge issued for its pending login.
+func (sess *session) onLoginResponse(contextID tag.ID, resp *amp.LoginResponse) error {
+	auth := sess.auth
+	if auth.pending == nil || contextID != auth.loginID {
+		return amp.ErrCode_LoginFailed.Error("no login challenge pending")
+	}
+
+	// A challenge can only be answered once
+	sess.auth = loginState{}
+
+	err := sess.host.opts.LoginVerifier.VerifyResponse(auth.pending, auth.challenge, resp.HashResponse)
+	if err == nil {
+		err = sess.completeLogin(auth.loginID, auth.pending)
+	}
+	if err != nil {
+		// The reason is only logged so that a client can't learn which users exist
+		sess.Log().Warnf("login failed for user %q: %v", auth.pending.UserLabel, err)
+		sess.sendErr(auth.loginID, amp.ErrCode_LoginFailed.Error("login failed"))
+
+		// Limit how many guesses a client gets
+		sess.loginFails++
+		maxFailures := sess.host.opts.MaxLoginFailures
+		if maxFailures <= 0 {
+			maxFailures = DefaultMaxLoginFailures
+		}
+		if sess.loginFails >= maxFailures {
+			sess.Log().Warnf("closing session after %d failed logins", sess.loginFails)
+			sess.Close()
+		}
+	}
+	return nil
+}
+
+// completeLogin sets the session's login and issues the client a checkpoint (step 4).
+func (sess *session) completeLogin(loginID tag.ID, login *amp.Login) error {
+	checkpoint, err := sess.host.opts.Checkpoints.Issue(login)
+	if err != nil {
+		return err
+	}
+
+	login.Checkpoint = checkpoint
+	sess.mu.Lock()
+	sess.login = *login
+	apps := make([]*appContext, 0, len(sess.apps))
+	for _, app := range sess.apps {
+		apps = append(apps, app)
+	}
+	sess.mu.Unlock()
+	sess.loggedIn = true
+
+	// App attrs are scoped by login, so running apps now watch the scope of the new login
+	for _, app := range apps {
+		app.watchScope()
+	}
+
+	return sess.sendLoginReply(loginID, checkpoint, amp.OpStatus_Synced)
+}
+
+func (sess *session) sendLoginReply(loginID tag.ID, reply tag.Value, status amp.OpStatus) error {
+	tx, err := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, reply)
+	if err != nil {
+		return err
+	}
+	tx.SetContextID(loginID)
+	tx.Status = status
+	return sess.SendTx(tx)
+}
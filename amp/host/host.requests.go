@@ -0,0 +1,145 @@
+package host
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// hostReq implements amp.Requester
+type hostReq struct {
+	params amp.Request // see Request()
+	sess   *session
+
+	mu        sync.Mutex
+	pin       amp.Pin // set once served
+	closing   bool    // set when the client closes this request
+	completed bool    // set once OnComplete() is called
+}
+
+func (sess *session) newRequest(reqID tag.ID, pinReq *amp.PinRequest) (*hostReq, error) {
+	if reqID.IsNil() {
+		return nil, amp.ErrCode_MalformedTx.Error("missing tx.GenesisID")
+	}
+
+	req := &hostReq{
+		sess: sess,
+		params: amp.Request{
+			PinRequest: *pinReq,
+			ID:         reqID,
+		},
+	}
+
+	if target := pinReq.PinTarget; target != nil && target.URL != "" {
+		var err error
+		req.params.URL, err = url.Parse(target.URL)
+		if err != nil {
+			return nil, amp.ErrCode_InvalidURI.Wrap(err)
+		}
+		req.params.Values = req.params.URL.Query()
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.requests[reqID] != nil {
+		return nil, amp.ErrCode_BadRequest.Errorf("request %s already open", reqID.Base32Suffix())
+	}
+	sess.requests[reqID] = req
+	return req, nil
+}
+
+func (sess *session) getRequest(reqID tag.ID) *hostReq {
+	if reqID.IsNil() {
+		return nil
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.requests[reqID]
+}
+
+// Implements amp.Requester
+func (req *hostReq) Request() *amp.Request {
+	return &req.params
+}
+
+// Implements amp.Requester
+func (req *hostReq) PushTx(tx *amp.TxMsg) error {
+	req.mu.Lock()
+	closed := req.closing || req.completed
+	req.mu.Unlock()
+
+	if closed {
+		tx.ReleaseRef()
+		return amp.ErrRequestClosed
+	}
+
+	tx.SetContextID(req.params.ID)
+	return req.sess.SendTx(tx)
+}
+
+// Implements amp.Requester
+func (req *hostReq) OnComplete(err error) {
+	req.mu.Lock()
+	if req.completed {
+		req.mu.Unlock()
+		return
+	}
+	req.completed = true
+	commitTx := req.params.CommitTx
+	req.params.CommitTx = nil
+	req.mu.Unlock()
+
+	if commitTx != nil {
+		commitTx.ReleaseRef()
+	}
+
+	sess := req.sess
+	sess.mu.Lock()
+	if sess.requests[req.params.ID] == req {
+		delete(sess.requests, req.params.ID)
+	}
+	sess.mu.Unlock()
+
+	if err == nil || err == amp.ErrRequestClosed {
+		tx := amp.NewTxMsg(true)
+		tx.SetContextID(req.params.ID)
+		tx.Status = amp.OpStatus_Closed
+		sess.SendTx(tx)
+	} else {
+		sess.sendErr(req.params.ID, err)
+	}
+}
+
+func (req *hostReq) setPin(pin amp.Pin) {
+	req.mu.Lock()
+	req.pin = pin
+	closing := req.closing
+	req.mu.Unlock()
+
+	// If a pin was served by the pinner but the request was closed in the meantime, close the pin.
+	if closing && pin != nil {
+		pin.Context().Close()
+	}
+}
+
+func (req *hostReq) getPin() amp.Pin {
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	return req.pin
+}
+
+// close is called when the client closes this request.
+func (req *hostReq) close() {
+	req.mu.Lock()
+	req.closing = true
+	pin := req.pin
+	req.mu.Unlock()
+
+	if pin != nil {
+		pin.Context().Close()
+	} else {
+		req.OnComplete(amp.ErrRequestClosed)
+	}
+}
@@ -0,0 +1,371 @@
+package host
+
+import (
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/media"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+)
+
+// session implements amp.Session
+type session struct {
+	task.Context
+	amp.Registry
+
+	host  *host
+	via   amp.Transport
+	txOut chan *amp.TxMsg // outbound txs -- see SendTx()
+
+	sessionID  tag.ID     // scopes the app attrs of an anonymous login (see settings.ScopeOf)
+	deviceUID  string     // if set, the device ID authenticated by the transport (see amp.AuthenticatedTransport)
+	auth       loginState // only accessed by the txReader
+	loggedIn   bool       // set once a login has completed, only accessed by the txReader
+	loginFails int        // failed login responses, only accessed by the txReader
+
+	mu       sync.Mutex
+	login    amp.Login
+	apps     map[tag.ID]*appContext // running app instances by AppSpec.ID
+	requests map[tag.ID]*hostReq    // open client requests by Request.ID
+}
+
+func startNewSession(h *host, parent amp.HostService, via amp.Transport) (*session, error) {
+	sess := &session{
+		Registry:  amp.NewRegistry(),
+		host:      h,
+		sessionID: tag.Now(),
+		via:       via,
+		txOut:     make(chan *amp.TxMsg, 8),
+		apps:      make(map[tag.ID]*appContext),
+		requests:  make(map[tag.ID]*hostReq),
+	}
+
+	if err := sess.Import(h.HostRegistry()); err != nil {
+		return nil, err
+	}
+	if auth, ok := via.(amp.AuthenticatedTransport); ok {
+		sess.deviceUID = auth.DeviceUID()
+		sess.login.DeviceUID = sess.deviceUID
+	}
+
+	var parentCtx task.Context = h
+	if parent != nil {
+		parentCtx = parent
+	}
+
+	var err error
+	sess.Context, err = parentCtx.StartChild(&task.Task{
+		Info: task.Info{
+			Label:     "session: " + via.Label(),
+			DebugMode: h.opts.DebugMode,
+		},
+		OnClosing: func() {
+			sess.via.Close()
+		},
+		OnClosed: func() {
+			h.opts.Settings.ReleaseSession(sess.sessionID)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = sess.Go("txWriter", sess.writeTxs); err == nil {
+		_, err = sess.Go("txReader", sess.readTxs)
+	}
+	if err != nil {
+		sess.Close()
+		return nil, err
+	}
+	return sess, nil
+}
+
+// Implements amp.Session
+func (sess *session) AssetPublisher() media.Publisher {
+	return sess.host.opts.Publisher
+}
+
+// Implements amp.Session
+func (sess *session) LoginInfo() amp.Login {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.login
+}
+
+// Implements amp.Session
+func (sess *session) SendTx(tx *amp.TxMsg) error {
+	if sess.host.opts.ValidateTxs {
+		if err := tx.Validate(); err != nil {
+			tx.ReleaseRef()
+			return err
+		}
+	}
+
+	select {
+	case <-sess.Closing():
+		tx.ReleaseRef()
+		return amp.ErrShuttingDown
+	default:
+	}
+
+	select {
+	case sess.txOut <- tx:
+		return nil
+	case <-sess.Closing():
+		tx.ReleaseRef()
+		return amp.ErrShuttingDown
+	}
+}
+
+// Implements amp.Session
+func (sess *session) GetAppInstance(appID tag.ID, autoCreate bool) (amp.AppInstance, error) {
+	sess.mu.Lock()
+	running := sess.apps[appID]
+	sess.mu.Unlock()
+
+	if running != nil {
+		return running.instance, nil
+	}
+	if !autoCreate {
+		return nil, amp.ErrCode_AppNotFound.Errorf("app not running: %s", appID)
+	}
+
+	app, err := sess.GetAppByTag(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	// NewAppInstance() is not called while locked since an app may make calls back into this session.
+	ctx, err := startAppContext(sess, app)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	running = sess.apps[appID]
+	if running == nil {
+		sess.apps[appID] = ctx
+	}
+	sess.mu.Unlock()
+
+	// Another request started this app in the meantime
+	if running != nil {
+		ctx.Close()
+		return running.instance, nil
+	}
+	return ctx.instance, nil
+}
+
+// Called from an appContext's OnClosing() to unregister itself, returning its AppInstance (if started).
+func (sess *session) onAppClosing(ctx *appContext) amp.AppInstance {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	appID := ctx.app.AppSpec.ID
+	if sess.apps[appID] == ctx {
+		delete(sess.apps, appID)
+	}
+	return ctx.instance
+}
+
+// writeTxs sends txs queued by SendTx() until the session closes.
+func (sess *session) writeTxs(ctx task.Context) {
+	for {
+		select {
+		case tx := <-sess.txOut:
+			if err := sess.via.SendTx(tx); err != nil {
+				if err != amp.ErrStreamClosed {
+					ctx.Log().Warnf("SendTx error: %v", err)
+				}
+				sess.Close()
+			}
+		case <-sess.Closing():
+			for {
+				select {
+				case tx := <-sess.txOut:
+					tx.ReleaseRef()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// readTxs receives and handles inbound txs until the transport closes.
+func (sess *session) readTxs(ctx task.Context) {
+	for {
+		tx, err := sess.via.RecvTx()
+		if err != nil {
+			if err != amp.ErrStreamClosed {
+				ctx.Log().Warnf("RecvTx error: %v", err)
+			}
+			break
+		}
+		err = sess.handleTx(tx)
+		if err != nil {
+			ctx.Log().Warnf("error handling tx %s: %v", tx.GenesisID().Base32Suffix(), err)
+			sess.sendErr(tx.GenesisID(), err)
+		}
+		tx.ReleaseRef()
+	}
+	sess.Close()
+}
+
+// handleTx routes an inbound tx.  The caller retains ownership of tx.
+func (sess *session) handleTx(tx *amp.TxMsg) error {
+	contextID := tx.ContextID()
+
+	// A client closing a request
+	if tx.Status == amp.OpStatus_Closed {
+		if req := sess.getRequest(contextID); req != nil {
+			req.close()
+		}
+		return nil
+	}
+
+	val, err := tx.CheckMetaAttr(sess)
+	if err != nil {
+		return err
+	}
+
+	switch v := val.(type) {
+	case *amp.Login:
+		return sess.onLogin(tx.GenesisID(), v)
+	case *amp.LoginResponse:
+		return sess.onLoginResponse(contextID, v)
+	}
+
+	if sess.host.opts.LoginVerifier != nil && !sess.loggedIn {
+		return amp.ErrCode_LoginFailed.Error("login required")
+	}
+
+	switch v := val.(type) {
+	case *amp.PinRequest:
+		return sess.servePinRequest(tx, v)
+	case nil:
+		return sess.serveCommit(tx)
+	default:
+		return amp.ErrCode_UnsupportedOp.Errorf("unsupported meta attr %T", v)
+	}
+}
+
+func (sess *session) servePinRequest(tx *amp.TxMsg, pinReq *amp.PinRequest) error {
+	req, err := sess.newRequest(tx.GenesisID(), pinReq)
+	if err != nil {
+		return err
+	}
+	sess.serveRequest(req, sess.getRequest(tx.ContextID()))
+	return nil
+}
+
+// serveCommit handles a tx that is to be merged into the pin of an open request (referenced by tx.ContextID).
+func (sess *session) serveCommit(tx *amp.TxMsg) error {
+	parent := sess.getRequest(tx.ContextID())
+	if parent == nil {
+		return amp.ErrCode_RequestNotFound.Errorf("no open request for tx context %s", tx.ContextID().Base32Suffix())
+	}
+
+	// A commit is only served by the pin of its parent request, so it is never applied via a new pin of the app
+	pin := parent.getPin()
+	if pin == nil {
+		return amp.ErrCode_CommitFailed.Errorf("request %s has no pin to commit to", tx.ContextID().Base32Suffix())
+	}
+
+	commit := &amp.PinRequest{
+		PinTarget: parent.params.PinTarget,
+		StateSync: amp.StateSync_None,
+	}
+	req, err := sess.newRequest(tx.GenesisID(), commit)
+	if err != nil {
+		return err
+	}
+	tx.AddRef()
+	req.params.CommitTx = tx
+	sess.servePin(req, pin)
+	return nil
+}
+
+// serveRequest dispatches a request to the Pin of the given parent request (if available) or to the app it invokes.
+func (sess *session) serveRequest(req *hostReq, parent *hostReq) {
+	var pinner amp.Pinner
+	if parent != nil {
+		pinner = parent.getPin()
+	}
+
+	if pinner == nil {
+		app, err := sess.appForRequest(&req.params)
+		if err != nil {
+			req.OnComplete(err)
+			return
+		}
+		// The instance's pin calls MakeReady() (see std.PinAndServe)
+		inst, err := sess.GetAppInstance(app.AppSpec.ID, true)
+		if err != nil {
+			req.OnComplete(err)
+			return
+		}
+		pinner = inst
+	}
+	sess.servePin(req, pinner)
+}
+
+// servePin has the given Pinner serve a request, completing the request if it fails.
+func (sess *session) servePin(req *hostReq, pinner amp.Pinner) {
+	pin, err := pinner.ServeRequest(req)
+	if err != nil {
+		req.OnComplete(err)
+		return
+	}
+	req.setPin(pin)
+}
+
+// appForRequest selects the app invoked by a request's URL, or by its target ID if no URL was given.
+//
+// The invocation is the URL host for "amp://{app-alias}/..." and the leading path element for "amp:{app-alias}/...".
+// For all other URL schemes, the scheme itself is the invocation (e.g. "file", "http").
+func (sess *session) appForRequest(req *amp.Request) (*amp.App, error) {
+	if req.URL == nil {
+		targetID := req.TargetID()
+		if targetID.IsNil() {
+			return nil, amp.ErrNothingToPin
+		}
+		return sess.GetAppByTag(targetID)
+	}
+
+	invocation := req.URL.Scheme
+	if invocation == "amp" {
+		invocation = req.URL.Host
+		if invocation == "" {
+			invocation = leadingPathElement(req.URL.Opaque)
+		}
+		if invocation == "" {
+			invocation = leadingPathElement(req.URL.Path)
+		}
+	}
+	return sess.GetAppForInvocation(invocation)
+}
+
+func leadingPathElement(path string) string {
+	for len(path) > 0 && path[0] == '/' {
+		path = path[1:]
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			return path[:i]
+		}
+	}
+	return path
+}
+
+// sendErr sends an error to the client in the context of the given tx ID and marks it as closed.
+func (sess *session) sendErr(contextID tag.ID, err error) {
+	errTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, amp.ErrorToValue(err))
+	if errTx == nil {
+		return
+	}
+	errTx.SetContextID(contextID)
+	errTx.Status = amp.OpStatus_Closed
+	sess.SendTx(errTx)
+}
@@ -0,0 +1,831 @@
+package host_test
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/host"
+	"github.com/amp-3d/amp-sdk-go/amp/internal/testhost"
+	"github.com/amp-3d/amp-sdk-go/amp/login"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+func startTestSession(t *testing.T) (amp.Host, amp.Session, amp.Transport) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+	return h, sess, client
+}
+
+func TestPinRequest(t *testing.T) {
+	_, sess, client := startTestSession(t)
+
+	pinTx, err := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/world",
+		},
+		StateSync: amp.StateSync_CloseOnSync,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+
+	tx := testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Synced || tx.ContextID() != reqID {
+		t.Fatalf("expected synced state for request, got status %v", tx.Status)
+	}
+	label := amp.Tag{}
+	if err = tx.LoadFirst(std.CellProperties.ID, &label); err != nil {
+		t.Fatalf("missing cell label: %v", err)
+	}
+	if label.Text != "hello /world" {
+		t.Errorf("unexpected label %q", label.Text)
+	}
+
+	tx = testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Closed || tx.ContextID() != reqID {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+
+	if _, err = sess.GetAppInstance(testhost.AppSpec.ID, false); err != nil {
+		t.Errorf("app instance not running: %v", err)
+	}
+}
+
+func TestPinRequestErrors(t *testing.T) {
+	_, _, client := startTestSession(t)
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://no-such-app/",
+		},
+	})
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+
+	tx := testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Closed || tx.ContextID() != reqID {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+	reqErr := amp.Err{}
+	if err := tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr); err != nil {
+		t.Fatalf("expected error attr: %v", err)
+	}
+	if reqErr.Code != amp.ErrCode_AppNotFound {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+}
+
+func TestMaintainAndClose(t *testing.T) {
+	_, _, client := startTestSession(t)
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp:host-test/maintained",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+
+	tx := testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Synced {
+		t.Fatalf("expected synced state, got status %v", tx.Status)
+	}
+
+	closeTx := amp.NewTxMsg(true)
+	closeTx.SetContextID(reqID)
+	closeTx.Status = amp.OpStatus_Closed
+	client.SendTx(closeTx)
+
+	tx = testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Closed || tx.ContextID() != reqID {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	secret := []byte("open sesame")
+
+	opts := host.DefaultOpts()
+	opts.LoginVerifier = login.NewHMACVerifier(func(login *amp.Login) ([]byte, error) {
+		if login.UserLabel != "alice" {
+			return nil, amp.ErrCode_LoginFailed.Errorf("unknown user %q", login.UserLabel)
+		}
+		return secret, nil
+	})
+	h := testhost.StartHost(t, opts)
+
+	pinTest := func(t *testing.T, client amp.Transport, wantStatus amp.OpStatus) {
+		t.Helper()
+		pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+			PinTarget: &amp.Tag{
+				URL: "amp://host-test/",
+			},
+			StateSync: amp.StateSync_CloseOnSync,
+		})
+		client.SendTx(pinTx)
+		tx := testhost.RecvTx(t, client)
+		if tx.Status != wantStatus {
+			t.Fatalf("expected status %v, got %v", wantStatus, tx.Status)
+		}
+		tx.ReleaseRef()
+		if wantStatus == amp.OpStatus_Synced {
+			testhost.RecvTx(t, client).ReleaseRef() // request closed
+		}
+	}
+
+	// Requests are refused until logged in
+	sess, client := testhost.StartSession(t, h)
+	pinTest(t, client, amp.OpStatus_Closed)
+
+	// Wrong secret or unknown user, which are indistinguishable to the client
+	_, wrongSecretErr := login.Login(client, &amp.Login{UserLabel: "alice"}, login.HMACResponder([]byte("wrong")))
+	if wrongSecretErr == nil {
+		t.Fatal("expected login to fail with the wrong secret")
+	}
+	_, unknownUserErr := login.Login(client, &amp.Login{UserLabel: "bob"}, login.HMACResponder(secret))
+	if unknownUserErr == nil {
+		t.Fatal("expected login to fail for an unknown user")
+	}
+	if wrongSecretErr.Error() != unknownUserErr.Error() {
+		t.Errorf("login errors differ: %q vs %q", wrongSecretErr, unknownUserErr)
+	}
+
+	checkpoint, err := login.Login(client, &amp.Login{UserLabel: "alice", DeviceUID: "device-1"}, login.HMACResponder(secret))
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if checkpoint.AuthToken == "" || checkpoint.AuthExpires <= time.Now().Unix() {
+		t.Fatalf("unexpected checkpoint %v", checkpoint)
+	}
+	if info := sess.LoginInfo(); info.UserLabel != "alice" || info.DeviceUID != "device-1" {
+		t.Errorf("unexpected LoginInfo %v", info)
+	}
+	pinTest(t, client, amp.OpStatus_Synced)
+
+	// A new session resumes from the checkpoint without a challenge
+	sess, client = testhost.StartSession(t, h)
+	_, err = login.Login(client, &amp.Login{UserLabel: "alice", DeviceUID: "device-1", Checkpoint: checkpoint}, nil)
+	if err != nil {
+		t.Fatalf("login resume failed: %v", err)
+	}
+	pinTest(t, client, amp.OpStatus_Synced)
+
+	// A failed login does not undo the login in effect
+	if _, err = login.Login(client, &amp.Login{UserLabel: "bob"}, login.HMACResponder(secret)); err == nil {
+		t.Fatal("expected login to fail for an unknown user")
+	}
+	if info := sess.LoginInfo(); info.UserLabel != "alice" {
+		t.Errorf("unexpected LoginInfo %v", info)
+	}
+	pinTest(t, client, amp.OpStatus_Synced)
+
+	// A checkpoint is bound to the identity it was issued for, so other devices are challenged
+	_, client = testhost.StartSession(t, h)
+	_, err = login.Login(client, &amp.Login{UserLabel: "alice", DeviceUID: "device-2", Checkpoint: checkpoint}, nil)
+	if err == nil {
+		t.Fatal("expected checkpoint to not resume for another device")
+	}
+
+	// A session is closed after too many failed logins
+	sess, client = testhost.StartSession(t, h)
+	for i := 0; i < host.DefaultMaxLoginFailures; i++ {
+		if _, err = login.Login(client, &amp.Login{UserLabel: "alice"}, login.HMACResponder([]byte("wrong"))); err == nil {
+			t.Fatal("expected login to fail with the wrong secret")
+		}
+	}
+	select {
+	case <-sess.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected session to close")
+	}
+}
+
+func TestAppAttrs(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess1, client1 := testhost.StartSession(t, h)
+	sess2, client2 := testhost.StartSession(t, h)
+
+	getApp := func(sess amp.Session) *testhost.App {
+		inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+		if err != nil {
+			t.Fatalf("GetAppInstance failed: %v", err)
+		}
+		return inst.(*testhost.App)
+	}
+	app1, app2 := getApp(sess1), getApp(sess2)
+
+	// Anonymous sessions do not share app attrs
+	attrSpec := testhost.AppSpec.With("theme").ID
+	if err := app1.GetAppAttr(attrSpec, &amp.Tag{}); err != amp.ErrAttrNotFound {
+		t.Fatalf("expected ErrAttrNotFound, got %v", err)
+	}
+	if err := app1.PutAppAttr(attrSpec, &amp.Tag{Text: "anon"}); err != nil {
+		t.Fatalf("PutAppAttr failed: %v", err)
+	}
+	if err := app2.GetAppAttr(attrSpec, &amp.Tag{}); err != amp.ErrAttrNotFound {
+		t.Fatalf("expected ErrAttrNotFound, got %v", err)
+	}
+
+	// Once logged in, app attrs are scoped to the user, even for apps already running
+	userUID := &amp.Tag{}
+	userUID.SetTagID(tag.Now())
+	for _, client := range []amp.Transport{client1, client2} {
+		if _, err := login.Login(client, &amp.Login{UserLabel: "alice", UserUID: userUID}, nil); err != nil {
+			t.Fatalf("login failed: %v", err)
+		}
+	}
+	if err := app1.GetAppAttr(attrSpec, &amp.Tag{}); err != amp.ErrAttrNotFound {
+		t.Fatalf("expected ErrAttrNotFound, got %v", err)
+	}
+	for len(app2.AttrsChanged) > 0 {
+		<-app2.AttrsChanged
+	}
+	if err := app1.PutAppAttr(attrSpec, &amp.Tag{Text: "dark"}); err != nil {
+		t.Fatalf("PutAppAttr failed: %v", err)
+	}
+
+	// The same user's app instance in another session is notified
+	select {
+	case changed := <-app2.AttrsChanged:
+		if changed != attrSpec {
+			t.Fatalf("unexpected attr changed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for OnAppAttrChanged")
+	}
+	val := amp.Tag{}
+	if err := app2.GetAppAttr(attrSpec, &val); err != nil || val.Text != "dark" {
+		t.Fatalf("GetAppAttr failed: %q %v", val.Text, err)
+	}
+}
+
+func TestPinNotify(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+
+	inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := make(chan *testhost.Cell, 1)
+	inst.(*testhost.App).Pinned = pinned
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/live",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+
+	cell := <-pinned
+	if tx := testhost.RecvTx(t, client); tx.Status != amp.OpStatus_Synced {
+		t.Fatalf("expected initial state, got status %v", tx.Status)
+	}
+
+	// Notifications within NotifyInterval are coalesced and only changed attrs are pushed
+	cellID := cell.Root().ID
+	cell.SetText("v1", "caption")
+	cell.Pin.Notify(cellID, std.CellLabel)
+	cell.SetText("v2", "caption")
+	cell.Pin.Notify(cellID, std.CellLabel)
+
+	tx := testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Synced || tx.ContextID() != reqID {
+		t.Fatalf("expected synced update, got status %v", tx.Status)
+	}
+	label := amp.Tag{}
+	if err = tx.Load(cellID, std.CellProperties.ID, std.CellLabel, &label); err != nil || label.Text != "v2" {
+		t.Fatalf("expected label v2, got %q (%v)", label.Text, err)
+	}
+	if len(tx.Ops) != 1 {
+		t.Fatalf("expected only the label to be pushed, got %d ops", len(tx.Ops))
+	}
+
+	// Notifying all attrs pushes the entire cell
+	cell.Pin.Notify(cellID)
+	if tx = testhost.RecvTx(t, client); len(tx.Ops) != 2 {
+		t.Fatalf("expected label and caption to be pushed, got %d ops", len(tx.Ops))
+	}
+
+	// Closing the pin completes the request
+	cell.Pin.Context().Close()
+	if tx = testhost.RecvTx(t, client); tx.Status != amp.OpStatus_Closed || tx.ContextID() != reqID {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+}
+
+func TestPinAttrs(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+
+	inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := make(chan *testhost.Cell, 1)
+	inst.(*testhost.App).Pinned = pinned
+
+	// Attrs not requested by the client are never pushed
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/live",
+		},
+		PinAttrs: []*amp.Tag{
+			amp.NewPinAttr(tag.Wildcard, amp.SelectOp_Include),
+			amp.NewPinAttr(std.CellCaption, amp.SelectOp_Exclude),
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	client.SendTx(pinTx)
+
+	cell := <-pinned
+	testhost.RecvTx(t, client)
+
+	cellID := cell.Root().ID
+	cell.SetText("v1", "caption")
+	cell.Pin.Notify(cellID)
+
+	tx := testhost.RecvTx(t, client)
+	label, caption := amp.Tag{}, amp.Tag{}
+	if err = tx.Load(cellID, std.CellProperties.ID, std.CellLabel, &label); err != nil || label.Text != "v1" {
+		t.Fatalf("expected label v1, got %q (%v)", label.Text, err)
+	}
+	if err = tx.Load(cellID, std.CellProperties.ID, std.CellCaption, &caption); err != amp.ErrPropertyNotFound {
+		t.Fatalf("expected caption to be excluded, got %q (%v)", caption.Text, err)
+	}
+
+	// Notifying only excluded attrs pushes nothing
+	cell.Pin.Notify(cellID, std.CellCaption)
+	cell.Pin.Context().Close()
+	if tx = testhost.RecvTx(t, client); tx.Status != amp.OpStatus_Closed {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+}
+
+func TestCommitTx(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+
+	inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := make(chan *testhost.Cell, 2)
+	inst.(*testhost.App).Pinned = pinned
+
+	// Pin the same cell twice
+	var reqIDs [2]tag.ID
+	for i := range reqIDs {
+		pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+			PinTarget: &amp.Tag{
+				URL: "amp://host-test/shared",
+			},
+			StateSync: amp.StateSync_Maintain,
+		})
+		reqIDs[i] = pinTx.GenesisID()
+		client.SendTx(pinTx)
+		<-pinned
+		testhost.RecvTx(t, client)
+	}
+	cellID := inst.(*testhost.App).Shared().Root().ID
+
+	commit := func(SI tag.ID, text string) tag.ID {
+		tx := amp.NewTxMsg(true)
+		tx.Upsert(cellID, std.CellProperties.ID, SI, &amp.Tag{Text: text})
+		tx.SetContextID(reqIDs[0])
+		commitID := tx.GenesisID()
+		client.SendTx(tx)
+		return commitID
+	}
+
+	// An accepted edit completes the commit and is pushed to the other pin
+	commitID := commit(std.CellLabel, "edited")
+	replies := make(map[tag.ID]*amp.TxMsg)
+	for len(replies) < 2 {
+		tx := testhost.RecvTx(t, client)
+		replies[tx.ContextID()] = tx
+	}
+	if tx := replies[commitID]; tx == nil || tx.Status != amp.OpStatus_Closed {
+		t.Fatalf("expected commit to complete")
+	}
+	if tx := replies[commitID]; tx.LoadFirst(amp.AttrSpec.With("Err").ID, &amp.Err{}) == nil {
+		t.Fatalf("expected commit to succeed")
+	}
+	label := amp.Tag{}
+	if tx := replies[reqIDs[1]]; tx == nil || tx.Load(cellID, std.CellProperties.ID, std.CellLabel, &label) != nil {
+		t.Fatalf("expected edit to be pushed to the other pin")
+	}
+	if label.Text != "edited" {
+		t.Fatalf("expected label %q, got %q", "edited", label.Text)
+	}
+
+	// A rejected edit is reported to the client
+	commitID = commit(std.CellCaption, "not editable")
+	tx := testhost.RecvTx(t, client)
+	reqErr := amp.Err{}
+	if tx.ContextID() != commitID || tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr) != nil {
+		t.Fatalf("expected commit to be rejected")
+	}
+	if reqErr.Code != amp.ErrCode_ViolatesAppendOnly {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+
+	// A commit to a request not (yet) pinned fails rather than being served by a new pin
+	pendingTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/pending",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	reqIDs[0] = pendingTx.GenesisID()
+	client.SendTx(pendingTx)
+	commitID = commit(std.CellLabel, "never applied")
+	tx = testhost.RecvTx(t, client)
+	reqErr = amp.Err{}
+	if tx.ContextID() != commitID || tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr) != nil {
+		t.Fatalf("expected commit to be rejected")
+	}
+	if reqErr.Code != amp.ErrCode_CommitFailed {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+
+	// A commit to several cells is not applied to any of them if one of them rejects it
+	folderTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/folder",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	folderReqID := folderTx.GenesisID()
+	client.SendTx(folderTx)
+	folder := <-pinned
+	recvSynced(t, client, folder.Root().ID)
+
+	tx = amp.NewTxMsg(true)
+	tx.Upsert(folder.Children[0].Root().ID, std.CellProperties.ID, std.CellLabel, &amp.Tag{Text: "never applied"})
+	tx.Upsert(folder.Children[1].Root().ID, std.CellProperties.ID, std.CellCaption, &amp.Tag{Text: "not editable"})
+	tx.SetContextID(folderReqID)
+	commitID = tx.GenesisID()
+	client.SendTx(tx)
+	tx = testhost.RecvTx(t, client)
+	reqErr = amp.Err{}
+	if tx.ContextID() != commitID || tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr) != nil {
+		t.Fatalf("expected commit to be rejected")
+	}
+	if reqErr.Code != amp.ErrCode_ViolatesAppendOnly {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+	if label := folder.Children[0].Label(); label != "child 0" {
+		t.Errorf("expected no edits to be applied, got label %q", label)
+	}
+
+	// A commit to a cell that is not pinned is reported as not found
+	tx = amp.NewTxMsg(true)
+	tx.Upsert(tag.Now(), std.CellProperties.ID, std.CellLabel, &amp.Tag{Text: "never applied"})
+	tx.SetContextID(folderReqID)
+	commitID = tx.GenesisID()
+	client.SendTx(tx)
+	tx = testhost.RecvTx(t, client)
+	reqErr = amp.Err{}
+	if tx.ContextID() != commitID || tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr) != nil {
+		t.Fatalf("expected commit to be rejected")
+	}
+	if reqErr.Code != amp.ErrCode_CellNotFound {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+}
+
+// childLabels returns the sorted labels of the children linked to (or unlinked from) the given cell by tx.
+func childLabels(t *testing.T, tx *amp.TxMsg, cellID tag.ID, opCode amp.TxOpCode) []string {
+	t.Helper()
+	var childIDs []tag.ID
+	for _, op := range tx.Ops {
+		if op.CellID == cellID && op.AttrID == std.CellChildren.ID && op.OpCode == opCode {
+			childIDs = append(childIDs, op.SI)
+		}
+	}
+	labels := make([]string, len(childIDs))
+	for i, childID := range childIDs {
+		label := amp.Tag{}
+		if opCode == amp.TxOpCode_UpsertElement {
+			if err := tx.Load(childID, std.CellProperties.ID, std.CellLabel, &label); err != nil {
+				t.Fatalf("missing label of linked child: %v", err)
+			}
+		}
+		labels[i] = label.Text
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+func TestPinWindow(t *testing.T) {
+	_, _, client := startTestSession(t)
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/folder?amp.offset=2&amp.limit=5",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+
+	// Children within the window are pushed in order over several txs
+	var folderID tag.ID
+	labels := []string{}
+	for _, status := range []amp.OpStatus{amp.OpStatus_Syncing, amp.OpStatus_Syncing, amp.OpStatus_Synced} {
+		tx := testhost.RecvTx(t, client)
+		if tx.Status != status {
+			t.Fatalf("expected status %v, got %v", status, tx.Status)
+		}
+		for _, op := range tx.Ops {
+			if op.CellID == amp.MetaNodeID {
+				folderID = op.SI
+			}
+		}
+		labels = append(labels, childLabels(t, tx, folderID, amp.TxOpCode_UpsertElement)...)
+	}
+	sort.Strings(labels)
+	if got := fmt.Sprint(labels); got != "[child 3 child 4 child 5 child 6 child 7]" {
+		t.Fatalf("unexpected children %v", got)
+	}
+
+	// Moving the window unlinks children leaving it and pushes children entering it, keeping its limit
+	target := &amp.Tag{
+		URL: "?amp.offset=4",
+	}
+	target.SetTagID(folderID)
+	moveTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: target,
+	})
+	moveTx.SetContextID(reqID)
+	moveID := moveTx.GenesisID()
+	client.SendTx(moveTx)
+
+	replies := make(map[tag.ID]*amp.TxMsg)
+	for len(replies) < 2 {
+		tx := testhost.RecvTx(t, client)
+		replies[tx.ContextID()] = tx
+	}
+	if tx := replies[moveID]; tx == nil || tx.Status != amp.OpStatus_Closed {
+		t.Fatalf("expected window request to complete")
+	}
+	tx := replies[reqID]
+	if tx == nil || tx.Status != amp.OpStatus_Synced {
+		t.Fatalf("expected window update")
+	}
+	if removed := childLabels(t, tx, folderID, amp.TxOpCode_DeleteElement); len(removed) != 2 {
+		t.Fatalf("expected 2 children to be unlinked, got %d", len(removed))
+	}
+	if got := fmt.Sprint(childLabels(t, tx, folderID, amp.TxOpCode_UpsertElement)); got != "[child 1 child 2]" {
+		t.Fatalf("unexpected children %v", got)
+	}
+
+	// A request targeting the pinned cell without window values completes without pinning it again
+	target = &amp.Tag{}
+	target.SetTagID(folderID)
+	noopTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: target,
+	})
+	noopTx.SetContextID(reqID)
+	noopID := noopTx.GenesisID()
+	client.SendTx(noopTx)
+	if tx = testhost.RecvTx(t, client); tx.ContextID() != noopID || tx.Status != amp.OpStatus_Closed || len(tx.Ops) != 0 {
+		t.Fatalf("expected request to complete without state, got status %v and %d ops", tx.Status, len(tx.Ops))
+	}
+}
+
+// recvSynced receives txs until one has OpStatus_Synced, returning the link ordering of each child linked to the given
+// cell and the number of children unlinked.  A child is keyed by its label, or by its Base32 ID if only relinked.
+func recvSynced(t *testing.T, client amp.Transport, cellID tag.ID) (links map[string]float32, unlinked int) {
+	t.Helper()
+	links = make(map[string]float32)
+	for {
+		tx := testhost.RecvTx(t, client)
+		var childIDs []tag.ID
+		var orderings []float32
+		for i, op := range tx.Ops {
+			if op.CellID != cellID || op.AttrID != std.CellChildren.ID {
+				continue
+			}
+			if op.OpCode == amp.TxOpCode_DeleteElement {
+				unlinked++
+				continue
+			}
+			link := amp.Tag{}
+			if err := tx.UnmarshalOpValue(i, &link); err != nil {
+				t.Fatalf("bad child link: %v", err)
+			}
+			childIDs = append(childIDs, op.SI)
+			orderings = append(orderings, link.Ordering)
+		}
+		for i, childID := range childIDs {
+			label := amp.Tag{}
+			if tx.Load(childID, std.CellProperties.ID, std.CellLabel, &label) != nil {
+				label.Text = childID.Base32()
+			}
+			links[label.Text] = orderings[i]
+		}
+		if tx.Status == amp.OpStatus_Synced {
+			return links, unlinked
+		}
+	}
+}
+
+func TestPinChildren(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+
+	inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := make(chan *testhost.Cell, 1)
+	inst.(*testhost.App).Pinned = pinned
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/folder",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	client.SendTx(pinTx)
+	folder := <-pinned
+	folderID := folder.Root().ID
+
+	// Children are linked in order of their SortKey
+	initial, _ := recvSynced(t, client, folderID)
+	if len(initial) != 10 {
+		t.Fatalf("expected 10 children, got %d", len(initial))
+	}
+	for i := 1; i < 10; i++ {
+		prev, curr := initial[fmt.Sprintf("child %d", i-1)], initial[fmt.Sprintf("child %d", i)]
+		if curr >= prev {
+			t.Fatalf("child %d not ordered before child %d", i, i-1)
+		}
+	}
+
+	// Changed children are pushed in the order notified
+	var notified, pushed []tag.ID
+	for _, i := range []int{7, 2, 9, 0} {
+		childID := folder.Children[i].Root().ID
+		notified = append(notified, childID)
+		folder.Pin.Notify(childID)
+	}
+	for len(pushed) < len(notified) {
+		tx := testhost.RecvTx(t, client)
+		for _, op := range tx.Ops {
+			if len(pushed) == 0 || pushed[len(pushed)-1] != op.CellID {
+				pushed = append(pushed, op.CellID)
+			}
+		}
+	}
+	if fmt.Sprint(pushed) != fmt.Sprint(notified) {
+		t.Fatalf("expected children to be pushed in the order notified")
+	}
+
+	// Adding a child only pushes the new child, ordered between its siblings
+	added := testhost.NewCell("added")
+	added.SetOrdering(5.5)
+	folder.Pin.AddChild(added)
+	links, _ := recvSynced(t, client, folderID)
+	if len(links) != 1 {
+		t.Fatalf("expected only the added child to be pushed, got %v", links)
+	}
+	if ordering := links["added"]; !(ordering > initial["child 5"] && ordering < initial["child 4"]) {
+		t.Fatalf("added child not ordered between its siblings")
+	}
+
+	// Moving a child only relinks the moved child
+	added.SetOrdering(100)
+	folder.Pin.MoveChild(added.Root().ID)
+	if links, _ = recvSynced(t, client, folderID); len(links) != 1 {
+		t.Fatalf("expected only the moved child to be relinked, got %v", links)
+	}
+
+	// Removing a child only unlinks the removed child
+	folder.Pin.RemoveChild(added.Root().ID)
+	if links, unlinked := recvSynced(t, client, folderID); len(links) != 0 || unlinked != 1 {
+		t.Fatalf("expected only the removed child to be unlinked, got %v and %d", links, unlinked)
+	}
+
+	// Inserting between the same siblings until rank precision is exhausted relinks all children in order
+	var order []*testhost.Cell
+	order = append(order, folder.Children[5:]...)
+	slices.Reverse(order)
+	for i := 0; i < 30; i++ {
+		fill := testhost.NewCell(fmt.Sprintf("fill %d", i))
+		fill.SetOrdering(5.5)
+		folder.Pin.AddChild(fill)
+		order = append(order, fill)
+	}
+	for i := 4; i >= 0; i-- {
+		order = append(order, folder.Children[i])
+	}
+	orderings := make(map[*testhost.Cell]float32)
+	for _, child := range folder.Children {
+		orderings[child] = initial[child.Label()]
+	}
+	for inOrder := false; !inOrder; {
+		links, _ = recvSynced(t, client, folderID)
+		for _, child := range order {
+			if ordering, linked := links[child.Label()]; linked {
+				orderings[child] = ordering
+			} else if ordering, linked = links[child.Root().ID.Base32()]; linked {
+				orderings[child] = ordering
+			}
+		}
+		inOrder = len(orderings) == len(order)
+		for i := 1; i < len(order) && inOrder; i++ {
+			inOrder = orderings[order[i-1]] < orderings[order[i]]
+		}
+	}
+}
+
+func TestSubPins(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+	sess, client := testhost.StartSession(t, h)
+
+	inst, err := sess.GetAppInstance(testhost.AppSpec.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := make(chan *testhost.Cell, 1)
+	inst.(*testhost.App).Pinned = pinned
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/tree",
+		},
+		StateSync: amp.StateSync_Maintain,
+	})
+	reqID := pinTx.GenesisID()
+	client.SendTx(pinTx)
+	tree := <-pinned
+	testhost.RecvTx(t, client)
+
+	// A grandchild is pinned by path, where the path's cells are not pinned
+	a := tree.Children[0]
+	b := a.Children[0]
+	target := &amp.Tag{
+		Tags: []*amp.Tag{{}},
+	}
+	target.Tags[0].SetTagID(a.Root().ID)
+	target.SetTagID(b.Root().ID)
+	subTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: target,
+		StateSync: amp.StateSync_Maintain,
+	})
+	subTx.SetContextID(reqID)
+	subID := subTx.GenesisID()
+	client.SendTx(subTx)
+
+	if cell := <-pinned; cell != b || cell.Pin.Parent != tree.Pin {
+		t.Fatalf("expected grandchild to be pinned under the parent pin")
+	}
+	tx := testhost.RecvTx(t, client)
+	label := amp.Tag{}
+	if tx.ContextID() != subID || tx.Load(b.Children[0].Root().ID, std.CellProperties.ID, std.CellLabel, &label) != nil {
+		t.Fatalf("expected state of pinned grandchild")
+	}
+	if label.Text != "c" {
+		t.Errorf("unexpected label %q", label.Text)
+	}
+
+	// Unknown paths are rejected
+	target.Tags[0].SetTagID(b.Root().ID)
+	badTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: target,
+	})
+	badTx.SetContextID(reqID)
+	badID := badTx.GenesisID()
+	client.SendTx(badTx)
+	reqErr := amp.Err{}
+	if tx = testhost.RecvTx(t, client); tx.ContextID() != badID || tx.LoadFirst(amp.AttrSpec.With("Err").ID, &reqErr) != nil {
+		t.Fatalf("expected bad path to be rejected")
+	}
+	if reqErr.Code != amp.ErrCode_CellNotFound {
+		t.Errorf("unexpected error code: %v", reqErr.Code)
+	}
+
+	// Closing the parent pin closes its sub-pins
+	tree.Pin.Context().Close()
+	closed := make(map[tag.ID]bool)
+	for len(closed) < 2 {
+		tx = testhost.RecvTx(t, client)
+		closed[tx.ContextID()] = tx.Status == amp.OpStatus_Closed
+	}
+	if !closed[reqID] || !closed[subID] {
+		t.Fatalf("expected parent and sub-pin to close")
+	}
+}
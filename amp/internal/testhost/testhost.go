@@ -0,0 +1,252 @@
+// Package testhost provides a test app along with helpers to start a host and sessions, shared by the tests of
+// the host and transport packages.
+package testhost
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/host"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/amp/transport"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+var AppSpec = amp.AppSpec.With("host-test")
+
+// App serves a Cell for each request, where the URL path selects the cell:
+//
+//	/folder   -- a cell having 10 children, "child 0" through "child 9", ordered in reverse
+//	/tree     -- a cell having a child "a", which has a child "b", which has a child "c"
+//	/shared   -- the same cell for every request (see App.Shared)
+//	/pending  -- no pin, leaving the request open as if still being served
+//	otherwise -- a cell labeled "hello <path>", followed by the session's DeviceUID if set
+type App struct {
+	std.App[*App]
+	AttrsChanged chan tag.ID // receives the attr spec of each app attr changed (see amp.AppAttrWatcher)
+	Pinned       chan *Cell  // if set, receives each cell once pinned
+
+	mu     sync.Mutex
+	shared *Cell // cell served for every pin of "/shared"
+}
+
+func (app *App) OnAppAttrChanged(attrSpec tag.ID) {
+	app.AttrsChanged <- attrSpec
+}
+
+// Shared returns the cell served for "/shared", or nil if not yet pinned.
+func (app *App) Shared() *Cell {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	return app.shared
+}
+
+func (app *App) ServeRequest(op amp.Requester) (amp.Pin, error) {
+	path := op.Request().URL.Path
+	if path == "/folder" {
+		cell := NewCell("folder")
+		for i := 0; i < 10; i++ {
+			child := NewCell(fmt.Sprintf("child %d", i))
+			child.ordering = float32(10 - i)
+			cell.Children = append(cell.Children, child)
+		}
+		return app.PinAndServe(cell, op)
+	}
+	if path == "/tree" {
+		cell := NewCell("tree")
+		parent := cell
+		for _, label := range []string{"a", "b", "c"} {
+			child := NewCell(label)
+			parent.Children = append(parent.Children, child)
+			parent = child
+		}
+		return app.PinAndServe(cell, op)
+	}
+	if path == "/pending" {
+		return nil, nil // left open without a pin, as if still being served
+	}
+	if path == "/shared" {
+		app.mu.Lock()
+		if app.shared == nil {
+			app.shared = NewCell("hello " + path)
+		}
+		cell := app.shared
+		app.mu.Unlock()
+		return app.PinAndServe(cell, op)
+	}
+
+	label := "hello " + path
+	if deviceUID := amp.AppContext(app).Session().LoginInfo().DeviceUID; deviceUID != "" {
+		label += " " + deviceUID
+	}
+	return app.PinAndServe(NewCell(label), op)
+}
+
+// Cell is a cell having a label and caption, where only the label is editable by a client.
+type Cell struct {
+	std.CellNode[*App]
+	Pin      *std.Pin[*App] // set once pinned
+	Children []*Cell        // not to be changed once pinned
+
+	mu       sync.Mutex
+	label    string
+	caption  string
+	ordering float32
+}
+
+// NewCell returns a new Cell having the given label.
+func NewCell(label string) *Cell {
+	return &Cell{
+		label: label,
+	}
+}
+
+func (cell *Cell) PinInto(dst *std.Pin[*App]) error {
+	cell.Pin = dst
+	dst.NotifyInterval = 100 * time.Millisecond
+	dst.ChildrenPerTx = 2
+	for _, child := range cell.Children {
+		dst.AddChild(child)
+	}
+	if pinned := dst.App.Pinned; pinned != nil {
+		pinned <- cell
+	}
+	return nil
+}
+
+func (cell *Cell) Child(childID tag.ID) std.Cell[*App] {
+	for _, child := range cell.Children {
+		if child.Root().ID == childID {
+			return child
+		}
+	}
+	return nil
+}
+
+func (cell *Cell) SortKey() std.SortKey {
+	cell.mu.Lock()
+	defer cell.mu.Unlock()
+	return std.SortKey{
+		Ordering: cell.ordering,
+	}
+}
+
+func (cell *Cell) MarshalAttrs(w std.CellWriter) {
+	cell.mu.Lock()
+	defer cell.mu.Unlock()
+
+	w.PutText(std.CellLabel, cell.label)
+	if cell.caption != "" {
+		w.PutText(std.CellCaption, cell.caption)
+	}
+}
+
+// ValidateEdits accepts edits to the cell label only.
+func (cell *Cell) ValidateEdits(edits []std.CellEdit) error {
+	for _, edit := range edits {
+		if edit.Op.AttrID != std.CellProperties.ID || edit.Op.SI != std.CellLabel {
+			return amp.ErrCode_ViolatesAppendOnly.Error("only the label is editable")
+		}
+	}
+	return nil
+}
+
+func (cell *Cell) CommitEdits(edits []std.CellEdit) error {
+	cell.mu.Lock()
+	defer cell.mu.Unlock()
+	for _, edit := range edits {
+		cell.label = edit.Value.(*amp.Tag).Text
+	}
+	return nil
+}
+
+func (cell *Cell) Label() string {
+	cell.mu.Lock()
+	defer cell.mu.Unlock()
+	return cell.label
+}
+
+func (cell *Cell) SetText(label, caption string) {
+	cell.mu.Lock()
+	cell.label = label
+	cell.caption = caption
+	cell.mu.Unlock()
+}
+
+// SetOrdering sets the ordering of this cell among its siblings (see std.SortKey).
+func (cell *Cell) SetOrdering(ordering float32) {
+	cell.mu.Lock()
+	cell.ordering = ordering
+	cell.mu.Unlock()
+}
+
+// StartHost starts a host serving App, which is closed when the test completes.
+func StartHost(t testing.TB, opts host.Opts) amp.Host {
+	t.Helper()
+
+	reg := amp.NewRegistry()
+	amp.RegisterBuiltinTypes(reg)
+	std.RegisterAttrs(reg)
+	reg.RegisterApp(&amp.App{
+		AppSpec: AppSpec,
+		NewAppInstance: func(ctx amp.AppContext) (amp.AppInstance, error) {
+			app := &App{
+				AttrsChanged: make(chan tag.ID, 8),
+			}
+			app.AppContext = ctx
+			app.Instance = app
+			return app, nil
+		},
+	})
+
+	opts.Registry = reg
+	opts.AppDataPath = t.TempDir()
+	h, err := host.StartNewHost(opts)
+	if err != nil {
+		t.Fatalf("StartNewHost failed: %v", err)
+	}
+	t.Cleanup(func() {
+		h.Close()
+		<-h.Done()
+	})
+	return h
+}
+
+// StartSession starts a session on the given host, returning the session and the client end of its transport.
+func StartSession(t testing.TB, h amp.Host) (amp.Session, amp.Transport) {
+	t.Helper()
+
+	pipeOpts := transport.DefaultPipeOpts()
+	pipeOpts.Serialize = true
+	client, server := transport.NewPipe(pipeOpts)
+	sess, err := h.StartNewSession(nil, server)
+	if err != nil {
+		t.Fatalf("StartNewSession failed: %v", err)
+	}
+	return sess, client
+}
+
+// RecvTx receives the next tx from the given transport, failing the test if none arrives within 5 seconds.
+func RecvTx(t testing.TB, via amp.Transport) *amp.TxMsg {
+	t.Helper()
+
+	var tx *amp.TxMsg
+	var err error
+	done := make(chan struct{})
+	go func() {
+		tx, err = via.RecvTx()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tx")
+	}
+	if err != nil {
+		t.Fatalf("RecvTx failed: %v", err)
+	}
+	return tx
+}
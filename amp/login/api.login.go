@@ -0,0 +1,91 @@
+// Package login implements the session login exchange between an amp client and amp.Host:
+//
+//  1. client -> host: amp.Login, sent as a meta attr on amp.MetaNodeID
+//  2. host -> client: amp.LoginChallenge, a random hash the client must answer
+//  3. client -> host: amp.LoginResponse, answering the challenge (e.g. via HMAC or an Ed25519 signature)
+//  4. host -> client: amp.LoginCheckpoint, an auth token valid until AuthExpires
+//
+// A client that reconnects with a valid checkpoint (via Login.Checkpoint) resumes its login, skipping steps 2 and 3.
+// Each tx following step 1 has its ContextID set to the GenesisID of the client's Login tx.
+// A failed login is answered with an amp.Err (ErrCode_LoginFailed) with OpStatus_Closed.
+package login
+
+import (
+	"crypto/ed25519"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+// DefaultCheckpointTTL is how long an issued LoginCheckpoint remains valid if no TTL is specified.
+const DefaultCheckpointTTL = 7 * 24 * time.Hour
+
+// Verifier checks a client's answer to a login challenge, allowing a Host to be backed by any user store.
+type Verifier interface {
+
+	// Returns nil if response correctly answers challenge for the given login.
+	// Typically, the login's UserUID or UserLabel is used to look up the user's credentials.
+	// A returned error is logged by the host, but the client is only told that its login failed.
+	VerifyResponse(login *amp.Login, challenge, response []byte) error
+}
+
+// Responder answers a login challenge on behalf of a client.
+type Responder func(challenge []byte) (response []byte, err error)
+
+// Checkpoints issues and resumes the auth tokens carried by amp.LoginCheckpoint.
+type Checkpoints interface {
+
+	// Issues a checkpoint for a login that has been verified.
+	Issue(login *amp.Login) (*amp.LoginCheckpoint, error)
+
+	// Returns nil if login.Checkpoint was issued for the given login and has not expired.
+	Resume(login *amp.Login) error
+}
+
+// NewHMACVerifier returns a Verifier expecting a response of HMAC-SHA256(secret, challenge),
+// where secret is the shared secret that lookup returns for a login.
+func NewHMACVerifier(lookup func(login *amp.Login) (secret []byte, err error)) Verifier {
+	return &hmacVerifier{
+		lookup: lookup,
+	}
+}
+
+// HMACResponder returns a Responder that answers a challenge for a NewHMACVerifier() Verifier.
+func HMACResponder(secret []byte) Responder {
+	return func(challenge []byte) ([]byte, error) {
+		return hmacSum(secret, challenge), nil
+	}
+}
+
+// NewEd25519Verifier returns a Verifier expecting a response that is an Ed25519 signature of the challenge,
+// where the signature is checked using the public key that lookup returns for a login.
+func NewEd25519Verifier(lookup func(login *amp.Login) (ed25519.PublicKey, error)) Verifier {
+	return &ed25519Verifier{
+		lookup: lookup,
+	}
+}
+
+// Ed25519Responder returns a Responder that answers a challenge for a NewEd25519Verifier() Verifier.
+func Ed25519Responder(key ed25519.PrivateKey) Responder {
+	return func(challenge []byte) ([]byte, error) {
+		return ed25519.Sign(key, challenge), nil
+	}
+}
+
+// NewCheckpoints returns Checkpoints that issue stateless tokens signed with the given key.
+// A token is bound to the login's UserUID, UserLabel, and DeviceUID.
+//
+// If key is nil, a random key is generated, so issued tokens only resume while the returned Checkpoints is in use.
+// If ttl <= 0, DefaultCheckpointTTL is used.
+func NewCheckpoints(key []byte, ttl time.Duration) (Checkpoints, error) {
+	return newCheckpoints(key, ttl)
+}
+
+// Login performs the client side of the login exchange over the given transport, blocking until it completes.
+// It should be called before any other txs are sent or received via the given transport.
+//
+// If login.Checkpoint is set, the host may resume the login without issuing a challenge.
+// respond may be nil if the host is not expected to issue a challenge.
+func Login(via amp.Transport, login *amp.Login, respond Responder) (*amp.LoginCheckpoint, error) {
+	return clientLogin(via, login, respond)
+}
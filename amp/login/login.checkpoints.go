@@ -0,0 +1,94 @@
+package login
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+// checkpoints implements Checkpoints
+//
+// A token is the base64 encoding of:
+//
+//	AuthExpires (8 bytes, big endian) | HMAC-SHA256(key, AuthExpires | login identity)
+type checkpoints struct {
+	key []byte
+	ttl time.Duration
+}
+
+func newCheckpoints(key []byte, ttl time.Duration) (*checkpoints, error) {
+	if key == nil {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultCheckpointTTL
+	}
+	return &checkpoints{
+		key: key,
+		ttl: ttl,
+	}, nil
+}
+
+func (cp *checkpoints) Issue(login *amp.Login) (*amp.LoginCheckpoint, error) {
+	expires := time.Now().Add(cp.ttl).Unix()
+
+	token := make([]byte, 8, 8+sha256.Size)
+	binary.BigEndian.PutUint64(token, uint64(expires))
+	token = append(token, cp.sign(expires, login)...)
+
+	return &amp.LoginCheckpoint{
+		AuthToken:   base64.RawURLEncoding.EncodeToString(token),
+		AuthExpires: expires,
+	}, nil
+}
+
+func (cp *checkpoints) Resume(login *amp.Login) error {
+	if login.Checkpoint == nil || login.Checkpoint.AuthToken == "" {
+		return amp.ErrNoAuthToken
+	}
+
+	token, err := base64.RawURLEncoding.DecodeString(login.Checkpoint.AuthToken)
+	if err != nil || len(token) != 8+sha256.Size {
+		return amp.ErrCode_AuthFailed.Error("malformed auth token")
+	}
+	expires := int64(binary.BigEndian.Uint64(token))
+	if !hmac.Equal(token[8:], cp.sign(expires, login)) {
+		return amp.ErrCode_AuthFailed.Error("invalid auth token")
+	}
+	if time.Now().Unix() >= expires {
+		return amp.ErrCode_AuthFailed.Error("auth token expired")
+	}
+	return nil
+}
+
+// sign returns the signature of the given expiration and login identity.
+func (cp *checkpoints) sign(expires int64, login *amp.Login) []byte {
+	var buf [8]byte
+	mac := hmac.New(sha256.New, cp.key)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(expires))
+	mac.Write(buf[:])
+
+	if login.UserUID != nil {
+		for _, n := range login.UserUID.TagID() {
+			binary.BigEndian.PutUint64(buf[:], n)
+			mac.Write(buf[:])
+		}
+	}
+
+	// Length-prefix variable fields so that field boundaries can't be shifted
+	for _, field := range []string{login.UserLabel, login.DeviceUID} {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(field)))
+		mac.Write(buf[:])
+		mac.Write([]byte(field))
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,83 @@
+package login
+
+import (
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+var (
+	challengeSpecID  = (&amp.LoginChallenge{}).TagSpec().ID
+	checkpointSpecID = (&amp.LoginCheckpoint{}).TagSpec().ID
+	errSpecID        = (&amp.Err{}).TagSpec().ID
+)
+
+func clientLogin(via amp.Transport, login *amp.Login, respond Responder) (*amp.LoginCheckpoint, error) {
+	loginTx, err := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, login)
+	if err != nil {
+		return nil, err
+	}
+	loginID := loginTx.GenesisID()
+	if err = via.SendTx(loginTx); err != nil {
+		return nil, err
+	}
+
+	for {
+		tx, err := via.RecvTx()
+		if err != nil {
+			return nil, err
+		}
+		reply, err := readReply(tx, loginID)
+		tx.ReleaseRef()
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := reply.(type) {
+		case *amp.LoginCheckpoint:
+			return v, nil
+		case *amp.Err:
+			return nil, v
+		case *amp.LoginChallenge:
+			if respond == nil {
+				return nil, amp.ErrCode_LoginFailed.Error("host issued a login challenge but no Responder was given")
+			}
+			response, err := respond(v.Hash)
+			if err != nil {
+				return nil, err
+			}
+			responseTx, err := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.LoginResponse{
+				HashResponse: response,
+			})
+			if err != nil {
+				return nil, err
+			}
+			responseTx.SetContextID(loginID)
+			if err = via.SendTx(responseTx); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// readReply reads the host's reply to a login, expected to be a single meta attr in the context of the login.
+func readReply(tx *amp.TxMsg, loginID tag.ID) (tag.Value, error) {
+	if tx.ContextID() != loginID || len(tx.Ops) != 1 || tx.Ops[0].CellID != amp.MetaNodeID {
+		return nil, amp.ErrCode_LoginFailed.Error("unexpected tx during login")
+	}
+
+	var reply tag.Value
+	switch tx.Ops[0].AttrID {
+	case challengeSpecID:
+		reply = &amp.LoginChallenge{}
+	case checkpointSpecID:
+		reply = &amp.LoginCheckpoint{}
+	case errSpecID:
+		reply = &amp.Err{}
+	default:
+		return nil, amp.ErrCode_LoginFailed.Error("unexpected attr during login")
+	}
+	if err := tx.UnmarshalOpValue(0, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
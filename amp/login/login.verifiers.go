@@ -0,0 +1,47 @@
+package login
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+func hmacSum(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// hmacVerifier implements Verifier
+type hmacVerifier struct {
+	lookup func(login *amp.Login) ([]byte, error)
+}
+
+func (v *hmacVerifier) VerifyResponse(login *amp.Login, challenge, response []byte) error {
+	secret, err := v.lookup(login)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(response, hmacSum(secret, challenge)) {
+		return amp.ErrCode_LoginFailed.Error("challenge response mismatch")
+	}
+	return nil
+}
+
+// ed25519Verifier implements Verifier
+type ed25519Verifier struct {
+	lookup func(login *amp.Login) (ed25519.PublicKey, error)
+}
+
+func (v *ed25519Verifier) VerifyResponse(login *amp.Login, challenge, response []byte) error {
+	pubKey, err := v.lookup(login)
+	if err != nil {
+		return err
+	}
+	if len(pubKey) != ed25519.PublicKeySize || !ed25519.Verify(pubKey, challenge, response) {
+		return amp.ErrCode_LoginFailed.Error("challenge signature mismatch")
+	}
+	return nil
+}
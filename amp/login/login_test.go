@@ -0,0 +1,94 @@
+package login_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/login"
+)
+
+func TestVerifiers(t *testing.T) {
+	challenge := []byte("challenge")
+	user := &amp.Login{
+		UserLabel: "alice",
+	}
+
+	secret := []byte("secret")
+	hmacVerifier := login.NewHMACVerifier(func(*amp.Login) ([]byte, error) {
+		return secret, nil
+	})
+
+	pubKey, privKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	ed25519Verifier := login.NewEd25519Verifier(func(*amp.Login) (ed25519.PublicKey, error) {
+		return pubKey, nil
+	})
+
+	tests := []struct {
+		name     string
+		verifier login.Verifier
+		respond  login.Responder
+		ok       bool
+	}{
+		{"hmac", hmacVerifier, login.HMACResponder(secret), true},
+		{"hmac wrong secret", hmacVerifier, login.HMACResponder([]byte("wrong")), false},
+		{"ed25519", ed25519Verifier, login.Ed25519Responder(privKey), true},
+		{"ed25519 wrong key", ed25519Verifier, login.Ed25519Responder(otherKey), false},
+	}
+	for _, test := range tests {
+		response, _ := test.respond(challenge)
+		err := test.verifier.VerifyResponse(user, challenge, response)
+		if (err == nil) != test.ok {
+			t.Errorf("%s: unexpected result: %v", test.name, err)
+		}
+	}
+}
+
+func TestCheckpoints(t *testing.T) {
+	cps, err := login.NewCheckpoints([]byte("key"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &amp.Login{
+		UserLabel: "alice",
+		UserUID:   &amp.Tag{TagID_1: 123},
+		DeviceUID: "device",
+	}
+	if err = cps.Resume(user); err != amp.ErrNoAuthToken {
+		t.Fatalf("expected ErrNoAuthToken, got %v", err)
+	}
+
+	user.Checkpoint, err = cps.Issue(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cps.Resume(user); err != nil {
+		t.Fatalf("expected checkpoint to resume: %v", err)
+	}
+
+	// A checkpoint only resumes the identity it was issued for and only with the key that signed it.
+	other := *user
+	other.DeviceUID = "other device"
+	if err = cps.Resume(&other); err == nil {
+		t.Error("expected checkpoint to fail for another device")
+	}
+	otherCps, _ := login.NewCheckpoints(nil, time.Hour)
+	if err = otherCps.Resume(user); err == nil {
+		t.Error("expected checkpoint to fail with another key")
+	}
+	user.Checkpoint.AuthToken = "garbage"
+	if err = cps.Resume(user); err == nil {
+		t.Error("expected malformed token to fail")
+	}
+
+	// AuthExpires has a resolution of one second, so this checkpoint has expired once issued.
+	shortLived, _ := login.NewCheckpoints([]byte("key"), time.Nanosecond)
+	user.Checkpoint, _ = shortLived.Issue(user)
+	if err = cps.Resume(user); err == nil {
+		t.Error("expected expired checkpoint to fail")
+	}
+}
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
 )
 
 func Global() amp.Registry {
@@ -9,6 +10,7 @@ func Global() amp.Registry {
 		gRegistry = amp.NewRegistry()
 	}
 	amp.RegisterBuiltinTypes(gRegistry)
+	std.RegisterAttrs(gRegistry)
 	return gRegistry
 }
 
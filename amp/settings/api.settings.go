@@ -0,0 +1,84 @@
+// Package settings implements the per-user, per-app attrs behind amp.AppContext.GetAppAttr() and PutAppAttr().
+//
+// Attrs are held by a Store, which caches each Scope while it is watched and persists it via a pluggable Backend.  A Store is typically
+// shared by all sessions of a host, so an app instance can be notified (see Store.Watch) when its settings are
+// edited from another session.
+package settings
+
+import (
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// Scope identifies the attrs of a user for a given app, so that key collision with other users or apps is not possible.
+type Scope struct {
+	AppSpec   tag.Spec // amp.App.AppSpec
+	UserUID   tag.ID   // amp.Login.UserUID (or nil if the user is anonymous)
+	SessionID tag.ID   // if the user is anonymous, identifies the session the attrs belong to
+}
+
+// ScopeOf returns the Scope of the given app and login, where the attrs of an anonymous login are scoped to the
+// given session so that they are not shared with other anonymous sessions.
+func ScopeOf(app *amp.App, login *amp.Login, sessionID tag.ID) Scope {
+	scope := Scope{
+		AppSpec: app.AppSpec,
+	}
+	if login.UserUID != nil {
+		scope.UserUID = login.UserUID.TagID()
+	}
+	if scope.UserUID.IsNil() {
+		scope.SessionID = sessionID
+	}
+	return scope
+}
+
+// Backend persists the attrs of each Scope, where each attr value is a marshalled tag.Value keyed by attr spec ID.
+type Backend interface {
+
+	// Reads all attrs stored for the given scope, returning an empty map if there are none.
+	ReadAttrs(scope Scope) (map[tag.ID][]byte, error)
+
+	// Replaces all attrs stored for the given scope.
+	WriteAttrs(scope Scope, attrs map[tag.ID][]byte) error
+}
+
+// Store gets and puts attrs for any Scope -- concurrency safe.
+type Store interface {
+
+	// Unmarshals the given attr into dst, returning amp.ErrAttrNotFound if it has not been put.
+	Get(scope Scope, attrSpec tag.ID, dst tag.Value) error
+
+	// Stores the given attr and then notifies the scope's watchers.
+	Put(scope Scope, attrSpec tag.ID, src tag.Value) error
+
+	// Calls fn with the attr spec ID of each attr subsequently put to the given scope until cancel is called.
+	// fn is called from the goroutine calling Put() and should not block.
+	Watch(scope Scope, fn func(attrSpec tag.ID)) (cancel func())
+
+	// Releases the attrs of each anonymous scope of the given session (see Scope.SessionID) once the session closes.
+	ReleaseSession(sessionID tag.ID)
+}
+
+// NewStore returns a Store backed by the given Backend.
+func NewStore(backend Backend) Store {
+	return newStore(backend)
+}
+
+// NewFileBackend returns a Backend storing each Scope as a JSON file under the given dir, typically the root
+// dir of each amp.AppContext.LocalDataPath(), at:
+//
+//	<dir>/<AppSpec.Canonic>/settings/<UserUID.Base32()>.json
+//
+// The attrs of an anonymous user are scoped to a session and so are not persisted.
+func NewFileBackend(dir string) Backend {
+	return &fileBackend{
+		dir: dir,
+	}
+}
+
+// NewMemoryBackend returns a Backend that does not persist attrs, useful for testing.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{
+		scopes: make(map[scopeKey]map[tag.ID][]byte),
+	}
+}
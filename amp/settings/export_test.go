@@ -0,0 +1,9 @@
+package settings
+
+// NumScopes returns the number of scopes whose attrs are retained by the given Store.
+func NumScopes(st Store) int {
+	s := st.(*store)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.scopes)
+}
@@ -0,0 +1,113 @@
+package settings
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+// fileBackend implements Backend
+type fileBackend struct {
+	dir string
+}
+
+// settingsFile is the JSON form of a Scope's attrs, where each attr is keyed by its attr spec ID in Base16 form.
+type settingsFile struct {
+	AppSpec string            `json:"AppSpec"`
+	Attrs   map[string][]byte `json:"Attrs"`
+}
+
+func (fb *fileBackend) pathOf(scope Scope) string {
+	return filepath.Join(fb.dir, scope.AppSpec.Canonic, "settings", scope.UserUID.Base32()+".json")
+}
+
+func (fb *fileBackend) ReadAttrs(scope Scope) (map[tag.ID][]byte, error) {
+	if scope.UserUID.IsNil() {
+		return nil, nil
+	}
+	buf, err := os.ReadFile(fb.pathOf(scope))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file settingsFile
+	if err = json.Unmarshal(buf, &file); err != nil {
+		return nil, amp.ErrCode_DataFailure.Errorf("settings: %v", err)
+	}
+	attrs := make(map[tag.ID][]byte, len(file.Attrs))
+	for key, data := range file.Attrs {
+		bin, err := hex.DecodeString(key)
+		if err != nil || len(bin) != 24 {
+			return nil, amp.ErrCode_DataFailure.Errorf("settings: bad attr key %q", key)
+		}
+		attrSpec, _ := tag.FromBytes(bin)
+		attrs[attrSpec] = data
+	}
+	return attrs, nil
+}
+
+func (fb *fileBackend) WriteAttrs(scope Scope, attrs map[tag.ID][]byte) error {
+	if scope.UserUID.IsNil() {
+		return nil // anonymous attrs only last as long as their session
+	}
+	file := settingsFile{
+		AppSpec: scope.AppSpec.Canonic,
+		Attrs:   make(map[string][]byte, len(attrs)),
+	}
+	for attrSpec, data := range attrs {
+		file.Attrs[attrSpec.Base16()] = data
+	}
+	buf, err := json.MarshalIndent(&file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so that a crash never leaves a partially written file
+	path := fb.pathOf(scope)
+	if err = utils.EnsureDirAndMaxPerms(filepath.Dir(path), utils.DefaultDirPerms); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err = utils.WriteFileWithMaxPerms(tmpPath, buf, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// memoryBackend implements Backend
+type memoryBackend struct {
+	mu     sync.Mutex
+	scopes map[scopeKey]map[tag.ID][]byte
+}
+
+func (mb *memoryBackend) ReadAttrs(scope Scope) (map[tag.ID][]byte, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	attrs := make(map[tag.ID][]byte)
+	for attrSpec, data := range mb.scopes[keyOf(scope)] {
+		attrs[attrSpec] = data
+	}
+	return attrs, nil
+}
+
+func (mb *memoryBackend) WriteAttrs(scope Scope, attrs map[tag.ID][]byte) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	stored := make(map[tag.ID][]byte, len(attrs))
+	for attrSpec, data := range attrs {
+		stored[attrSpec] = data
+	}
+	mb.scopes[keyOf(scope)] = stored
+	return nil
+}
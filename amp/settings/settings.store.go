@@ -0,0 +1,163 @@
+package settings
+
+import (
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// scopeKey identifies a Scope within a store.
+type scopeKey struct {
+	appID     tag.ID
+	userUID   tag.ID
+	sessionID tag.ID
+}
+
+func keyOf(scope Scope) scopeKey {
+	return scopeKey{
+		appID:     scope.AppSpec.ID,
+		userUID:   scope.UserUID,
+		sessionID: scope.SessionID,
+	}
+}
+
+// store implements Store
+type store struct {
+	backend Backend
+
+	mu       sync.Mutex
+	scopes   map[scopeKey]map[tag.ID][]byte // attrs of each scope watched or of an anonymous session
+	watchers map[scopeKey]map[*watcher]struct{}
+}
+
+type watcher struct {
+	fn func(attrSpec tag.ID)
+}
+
+func newStore(backend Backend) *store {
+	return &store{
+		backend:  backend,
+		scopes:   make(map[scopeKey]map[tag.ID][]byte),
+		watchers: make(map[scopeKey]map[*watcher]struct{}),
+	}
+}
+
+// attrs returns the attrs of the given scope, reading them from the backend as needed.
+// The attrs are retained while the scope is watched, or until ReleaseSession() if the scope is anonymous since the
+// backend may not persist it.  Called while st.mu is locked.
+func (st *store) attrs(scope Scope) (map[tag.ID][]byte, error) {
+	key := keyOf(scope)
+	attrs := st.scopes[key]
+	if attrs == nil {
+		var err error
+		if attrs, err = st.backend.ReadAttrs(scope); err != nil {
+			return nil, err
+		}
+		if attrs == nil {
+			attrs = make(map[tag.ID][]byte)
+		}
+		if len(st.watchers[key]) > 0 || key.sessionID.IsSet() {
+			st.scopes[key] = attrs
+		}
+	}
+	return attrs, nil
+}
+
+func (st *store) Get(scope Scope, attrSpec tag.ID, dst tag.Value) error {
+	st.mu.Lock()
+	attrs, err := st.attrs(scope)
+	var data []byte
+	if err == nil {
+		data = attrs[attrSpec]
+	}
+	st.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return amp.ErrAttrNotFound
+	}
+	return dst.Unmarshal(data)
+}
+
+func (st *store) Put(scope Scope, attrSpec tag.ID, src tag.Value) error {
+	data, err := src.MarshalToStore(nil)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	st.mu.Lock()
+	attrs, err := st.attrs(scope)
+	if err == nil {
+		prev, existed := attrs[attrSpec]
+		attrs[attrSpec] = data
+		if err = st.backend.WriteAttrs(scope, attrs); err != nil {
+			if existed {
+				attrs[attrSpec] = prev
+			} else {
+				delete(attrs, attrSpec)
+			}
+		}
+	}
+	var notify []*watcher
+	if err == nil {
+		for w := range st.watchers[keyOf(scope)] {
+			notify = append(notify, w)
+		}
+	}
+	st.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	for _, w := range notify {
+		w.fn(attrSpec)
+	}
+	return nil
+}
+
+func (st *store) Watch(scope Scope, fn func(attrSpec tag.ID)) (cancel func()) {
+	key := keyOf(scope)
+	w := &watcher{
+		fn: fn,
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	watchers := st.watchers[key]
+	if watchers == nil {
+		watchers = make(map[*watcher]struct{})
+		st.watchers[key] = watchers
+	}
+	watchers[w] = struct{}{}
+
+	return func() {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+
+		delete(watchers, w)
+		if len(st.watchers[key]) == 0 {
+			delete(st.watchers, key)
+			if key.sessionID.IsNil() {
+				delete(st.scopes, key)
+			}
+		}
+	}
+}
+
+func (st *store) ReleaseSession(sessionID tag.ID) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for key := range st.scopes {
+		if key.sessionID == sessionID {
+			delete(st.scopes, key)
+		}
+	}
+}
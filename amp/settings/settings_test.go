@@ -0,0 +1,110 @@
+package settings_test
+
+import (
+	"testing"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/settings"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	themeID := amp.AttrSpec.With("theme").ID
+	alice := settings.Scope{
+		AppSpec: amp.AppSpec.With("settings-test"),
+		UserUID: tag.ID{0, 0, 1},
+	}
+	bob := alice
+	bob.UserUID = tag.ID{0, 0, 2}
+
+	for _, test := range []struct {
+		name       string
+		newBackend func() settings.Backend
+		persists   bool
+	}{
+		{"File", func() settings.Backend { return settings.NewFileBackend(dir) }, true},
+		{"Memory", settings.NewMemoryBackend, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			st := settings.NewStore(test.newBackend())
+
+			var changes []tag.ID
+			cancel := st.Watch(alice, func(attrSpec tag.ID) {
+				changes = append(changes, attrSpec)
+			})
+
+			if err := st.Get(alice, themeID, &amp.Tag{}); err != amp.ErrAttrNotFound {
+				t.Fatalf("expected ErrAttrNotFound, got %v", err)
+			}
+			if err := st.Put(alice, themeID, &amp.Tag{Text: "dark"}); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			if err := st.Put(bob, themeID, &amp.Tag{Text: "light"}); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			if len(changes) != 1 || changes[0] != themeID {
+				t.Fatalf("expected one change, got %v", changes)
+			}
+			cancel()
+			st.Put(alice, themeID, &amp.Tag{Text: "dark"})
+			if len(changes) != 1 {
+				t.Fatalf("expected no change after cancel")
+			}
+
+			check := func(st settings.Store, scope settings.Scope, want string) {
+				t.Helper()
+				val := amp.Tag{}
+				if err := st.Get(scope, themeID, &val); err != nil || val.Text != want {
+					t.Errorf("expected %q, got %q (%v)", want, val.Text, err)
+				}
+			}
+			check(st, alice, "dark")
+			check(st, bob, "light")
+
+			// Anonymous attrs are scoped to a session
+			anon1 := settings.Scope{
+				AppSpec:   alice.AppSpec,
+				SessionID: tag.ID{0, 0, 3},
+			}
+			anon2 := anon1
+			anon2.SessionID = tag.ID{0, 0, 4}
+			if err := st.Put(anon1, themeID, &amp.Tag{Text: "anon"}); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			check(st, anon1, "anon")
+			if err := st.Get(anon2, themeID, &amp.Tag{}); err != amp.ErrAttrNotFound {
+				t.Fatalf("expected ErrAttrNotFound, got %v", err)
+			}
+
+			// Only watched and anonymous scopes are retained, until unwatched or the session is released
+			if n := settings.NumScopes(st); n != 2 {
+				t.Fatalf("expected only the anonymous scopes to be retained, got %d", n)
+			}
+			cancel = st.Watch(alice, func(tag.ID) {})
+			check(st, alice, "dark")
+			if n := settings.NumScopes(st); n != 3 {
+				t.Fatalf("expected the watched scope to be retained, got %d", n)
+			}
+			cancel()
+			st.ReleaseSession(anon1.SessionID)
+			st.ReleaseSession(anon2.SessionID)
+			if n := settings.NumScopes(st); n != 0 {
+				t.Fatalf("expected no scopes to be retained, got %d", n)
+			}
+			if err := st.Get(anon1, themeID, &amp.Tag{}); test.persists && err != amp.ErrAttrNotFound {
+				t.Fatalf("expected released anonymous attrs to be gone, got %v", err)
+			}
+			check(st, alice, "dark")
+
+			if test.persists {
+				reopened := settings.NewStore(test.newBackend())
+				check(reopened, alice, "dark")
+				check(reopened, bob, "light")
+				if err := reopened.Get(anon1, themeID, &amp.Tag{}); err != amp.ErrAttrNotFound {
+					t.Fatalf("expected anonymous attrs to not persist, got %v", err)
+				}
+			}
+		})
+	}
+}
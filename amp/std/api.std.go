@@ -3,6 +3,10 @@
 package std
 
 import (
+	"reflect"
+	"sync"
+	"time"
+
 	"github.com/amp-3d/amp-sdk-go/amp"
 	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
 	"github.com/amp-3d/amp-sdk-go/stdlib/task"
@@ -26,25 +30,142 @@ type Cell[AppT amp.AppInstance] interface {
 	MarshalAttrs(w CellWriter)
 }
 
+// ParentCell is optionally implemented by a Cell whose children can be found without pinning it.
+// This allows a client to pin a descendant of a pinned cell by path (see Pin.Resolve).
+type ParentCell[AppT amp.AppInstance] interface {
+
+	// Returns the child cell having the given ID, or nil if not found.
+	Child(childID tag.ID) Cell[AppT]
+}
+
+// CellCommitter is optionally implemented by a Cell that accepts edits committed by a client (see amp.Request.CommitTx),
+// such as a cell presented as CellKind_EditField or CellKind_Toggle.
+//
+// A commit is applied in two phases so that it is applied to all its cells or to none: each cell's edits are first
+// validated via ValidateEdits() and only once all are valid is CommitEdits() called on each cell.
+type CellCommitter interface {
+
+	// Checks if the given edits can be applied to this cell, called from the goroutine serving the commit.
+	// If an error is returned, the commit is rejected, typically with ErrCode_ViolatesAppendOnly for an attr that is
+	// not editable.
+	ValidateEdits(edits []CellEdit) error
+
+	// Applies the given edits to this cell, previously validated via ValidateEdits().
+	// Once applied, the edited attrs are re-marshalled via Cell.MarshalAttrs() to the cell's other maintaining pins.
+	CommitEdits(edits []CellEdit) error
+}
+
+// CellEdit is an op committed by a client to a cell.
+type CellEdit struct {
+	Op    *amp.TxOp // READ ONLY
+	Value tag.Value // unmarshalled via Registry.MakeValue(Op.AttrID), or nil if Op is not an upsert
+}
+
 // CellNode is a helper for implementing the Cell interface.
 type CellNode[AppT amp.AppInstance] struct {
 	ID tag.ID
+
+	mu   sync.Mutex
+	pins map[*Pin[AppT]]struct{} // StateSync_Maintain pins containing this cell
 }
 
 // Wraps the pinned state of a cell -- implements amp.Pin
+//
+// A request served by a pin (i.e. sent in the context of the pin's request) pins a descendant of the pinned cell as
+// a sub-pin, started as a child task.Context of the pin nearest to it (see Pin.Resolve).  Closing a pin closes its sub-pins.
 type Pin[AppT amp.AppInstance] struct {
-	Op   amp.Requester // originating request
-	Cell Cell[AppT]    // pinned cell
-	App  AppT          // parent app instance
-	Sync amp.StateSync // Op.Request().StateSync
+	Op     amp.Requester // originating request
+	Cell   Cell[AppT]    // pinned cell
+	App    AppT          // parent app instance
+	Parent *Pin[AppT]    // pin that started this sub-pin (or nil if started by the app)
+	Sync   amp.StateSync // Op.Request().StateSync
+
+	// Minimum time between updates pushed for Notify() -- if 0, DefaultNotifyInterval is used.
+	// Notifications arriving in the meantime are coalesced into a single update.
+	NotifyInterval time.Duration
+
+	// Max number of children pushed per tx -- if 0, DefaultChildrenPerTx is used.
+	// Children in excess are pushed in subsequent txs, where each tx but the last has OpStatus_Syncing.
+	ChildrenPerTx int
+
+	ctx      task.Context       // task context for this pin
+	pinAttrs *amp.AttrSelector  // attrs requested via PinRequest.PinAttrs (nil denotes all attrs)
+	linked   map[tag.ID]float32 // rank of each child linked to the pinned cell (accessed by the pin's task only)
 
-	children map[tag.ID]Cell[AppT] // child cells
-	ctx      task.Context          // task context for this pin
+	mu          sync.Mutex
+	children    childList[AppT]                // child cells
+	window      Window                         // children requested to be pushed
+	maintaining bool                           // set while pushing updates to a StateSync_Maintain pin
+	relink      bool                           // set when the children or window have changed
+	subPins     map[*Pin[AppT]]struct{}        // open pins started by this pin
+	dirty       map[tag.ID]map[tag.ID]struct{} // attrs to push by CellID (a nil set denotes all attrs)
+	dirtyIDs    []tag.ID                       // CellIDs of dirty in the order first marked
+	notify      chan struct{}                  // signaled when dirty or relink is set
 }
 
+const (
+	DefaultNotifyInterval = 50 * time.Millisecond // default Pin.NotifyInterval
+	DefaultChildrenPerTx  = 256                   // default Pin.ChildrenPerTx
+)
+
+// OrderedCell is optionally implemented by a child cell to order it among its siblings.
+// Children are ordered by SortKey and then by the order they were added (see Pin.AddChild).
+//
+// Each child is linked to its pinned parent via a CellChildren element having a amp.Tag value, where Tag.Ordering
+// ascends with the child order.  This means a child added, moved, or removed is pushed without relinking its siblings.
+type OrderedCell interface {
+	SortKey() SortKey
+}
+
+// SortKey orders a cell among its siblings, comparing Ordering, then Label, and then TimeCreated.
+type SortKey struct {
+	Ordering    float32 // see amp.Tag.Ordering
+	Label       string
+	TimeCreated time.Time
+}
+
+// Window selects a range of a pinned cell's ordered children so that a client can page through a cell having many
+// children.  A window is requested via the values of PinRequest.PinTarget.URL, e.g.
+// "amp://app/path?amp.offset=100&amp.limit=50".
+//
+// To move the window of an open StateSync_Maintain pin, a client sends a PinRequest in the context of the pin's
+// request having a PinTarget that targets the pinned cell and whose URL has the new window values (e.g.
+// "?amp.offset=150").  A value not given is left as is.  The pin then deletes the links of children leaving the window
+// and pushes the children entering it.
+type Window struct {
+	Offset int // index of the first child in the window
+	Limit  int // max number of children in the window (or 0 if unlimited)
+}
+
+// URL query keys of a Window
+const (
+	WindowOffset = "amp.offset"
+	WindowLimit  = "amp.limit"
+)
+
 type CellWriter interface {
-	Upsert(op *amp.TxOp, val tag.Value)
+	Upsert(op *amp.TxOp, val tag.Value) // if op.CellID is nil, the ID of the cell being marshalled is used
 
 	PutText(propertyID tag.ID, val string)
 	PutItem(propertyID tag.ID, val tag.Value)
 }
+
+// CellBinding marshals the fields of a struct type as the attrs of a cell, eliminating the need to write each attr
+// in Cell.MarshalAttrs() -- see MarshalStruct() and UnmarshalStruct().
+//
+// A field is bound via a struct tag having the form:
+//
+//	`amp:"<tag.Spec>[,attr][,omitempty]"`
+//
+// By default, a field is bound to the cell property having the ID CellProperty.With(<tag.Spec>), e.g. a field tagged
+// `amp:"text.Tag.label"` is bound to CellLabel.  If "attr" is given, the field is instead bound to the attr having the
+// ID amp.AttrSpec.With(<tag.Spec>).  If "omitempty" is given, the field is not marshalled when it has a zero value.
+//
+// A bound field is either a string (marshalled as amp.Tag.Text) or a type implementing tag.Value (or a pointer to one).
+type CellBinding struct {
+	typ    reflect.Type
+	fields []fieldBinding
+}
+
+// BindingTag is the struct tag key of a field bound by a CellBinding.
+const BindingTag = "amp"
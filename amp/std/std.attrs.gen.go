@@ -0,0 +1,64 @@
+// Code generated by amp-attrgen from std.proto. DO NOT EDIT.
+
+package std
+
+import (
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+func (v *Position) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *Position) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("Position")
+}
+
+func (v *Position) New() tag.Value {
+	return &Position{}
+}
+
+func (v *Revision) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *Revision) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("Revision")
+}
+
+func (v *Revision) New() tag.Value {
+	return &Revision{}
+}
+
+func (v *TimeTag) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *TimeTag) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("TimeTag")
+}
+
+func (v *TimeTag) New() tag.Value {
+	return &TimeTag{}
+}
+
+func (v *FSInfo) MarshalToStore(in []byte) (out []byte, err error) {
+	return amp.MarshalPbToStore(v, in)
+}
+
+func (v *FSInfo) TagSpec() tag.Spec {
+	return amp.AttrSpec.With("FSInfo")
+}
+
+func (v *FSInfo) New() tag.Value {
+	return &FSInfo{}
+}
+
+// registerAttrs registers a prototype of each attr declared in std.proto.
+func registerAttrs(reg amp.Registry) {
+	reg.RegisterPrototype(amp.AttrSpec, &Position{}, "Position")
+	reg.RegisterPrototype(amp.AttrSpec, &Revision{}, "Revision")
+	reg.RegisterPrototype(amp.AttrSpec, &TimeTag{}, "TimeTag")
+	reg.RegisterPrototype(amp.AttrSpec, &FSInfo{}, "FSInfo")
+}
@@ -1,5 +1,7 @@
 package std
 
+//go:generate go run github.com/amp-3d/amp-sdk-go/cmd/amp-attrgen -register registerAttrs std.proto
+
 import (
 	"time"
 
@@ -33,6 +35,14 @@ var (
 	CellPortrait      = CellProperty.With("portrait").ID
 )
 
+// RegisterAttrs registers a prototype of each attr declared in std.proto along with CellProperties.
+func RegisterAttrs(reg amp.Registry) {
+	registerAttrs(reg)
+
+	// Cell properties are keyed by SI and are Tag valued (e.g. a cell label) so that client edits can be unmarshalled
+	reg.RegisterPrototype(amp.AttrSpec, &amp.Tag{}, "cell-properties")
+}
+
 const (
 	// URL prefix for a glyph and is typically followed by a media (mime) type.
 	GenericGlyphURL = "amp:glyph/"
@@ -53,42 +63,6 @@ type PinnableAttr struct {
 	Spec tag.Spec
 }
 
-func (v *Position) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *Position) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("Position")
-}
-
-func (v *Position) New() tag.Value {
-	return &Position{}
-}
-
-func (v *Revision) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *Revision) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("Revision")
-}
-
-func (v *Revision) New() tag.Value {
-	return &Revision{}
-}
-
-func (v *TimeTag) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *TimeTag) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("TimeTag")
-}
-
-func (v *TimeTag) New() tag.Value {
-	return &TimeTag{}
-}
-
 func (v *TimeTag) SetFromTime(t time.Time) {
 	tag := tag.FromTime(t, false)
 	v.TagID_0 = int64(tag[0])
@@ -96,18 +70,6 @@ func (v *TimeTag) SetFromTime(t time.Time) {
 	v.TagID_2 = tag[2]
 }
 
-func (v *FSInfo) MarshalToStore(in []byte) (out []byte, err error) {
-	return amp.MarshalPbToStore(v, in)
-}
-
-func (v *FSInfo) TagSpec() tag.Spec {
-	return amp.AttrSpec.With("FSInfo")
-}
-
-func (v *FSInfo) New() tag.Value {
-	return &FSInfo{}
-}
-
 func (v *FSInfo) SetModifiedAt(t time.Time) {
 	tag := tag.FromTime(t, false)
 	v.ModifiedAt = int64(tag[0])
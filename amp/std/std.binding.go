@@ -0,0 +1,219 @@
+package std
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// fieldBinding maps a struct field to a cell property or attr.
+type fieldBinding struct {
+	index     int
+	name      string
+	attrID    tag.ID // CellProperties.ID for a cell property
+	SI        tag.ID // property ID for a cell property, otherwise nil
+	isText    bool   // if set, the field is a string marshalled as amp.Tag.Text
+	isPtr     bool   // if set, the field is a pointer to a tag.Value
+	omitEmpty bool
+}
+
+var gBindings sync.Map // reflect.Type => *CellBinding
+
+// BindCell returns the CellBinding of the given struct type, where src is a struct or a pointer to one.
+func BindCell(src any) (*CellBinding, error) {
+	typ := reflect.TypeOf(src)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, amp.ErrCode_BadSchema.Errorf("BindCell: expected struct, got %v", typ)
+	}
+
+	if binding, exists := gBindings.Load(typ); exists {
+		return binding.(*CellBinding), nil
+	}
+	binding, err := makeBinding(typ)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := gBindings.LoadOrStore(typ, binding)
+	return actual.(*CellBinding), nil
+}
+
+var tagValueType = reflect.TypeOf((*tag.Value)(nil)).Elem()
+
+func makeBinding(typ reflect.Type) (*CellBinding, error) {
+	binding := &CellBinding{
+		typ: typ,
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		spec, hasTag := field.Tag.Lookup(BindingTag)
+		if !hasTag || spec == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, amp.ErrCode_BadSchema.Errorf("BindCell: %s.%s is not exported", typ.Name(), field.Name)
+		}
+
+		fb := fieldBinding{
+			index: i,
+			name:  field.Name,
+		}
+		spec, opts, _ := strings.Cut(spec, ",")
+		isAttr := false
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "":
+			case "attr":
+				isAttr = true
+			case "omitempty":
+				fb.omitEmpty = true
+			default:
+				return nil, amp.ErrCode_BadSchema.Errorf("BindCell: %s.%s has unknown option %q", typ.Name(), field.Name, opt)
+			}
+		}
+		if spec == "" {
+			return nil, amp.ErrCode_BadSchema.Errorf("BindCell: %s.%s is missing a tag spec", typ.Name(), field.Name)
+		}
+		if isAttr {
+			fb.attrID = amp.AttrSpec.With(spec).ID
+		} else {
+			fb.attrID = CellProperties.ID
+			fb.SI = CellProperty.With(spec).ID
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.String:
+			fb.isText = true
+		case field.Type.Kind() == reflect.Pointer && field.Type.Implements(tagValueType):
+			fb.isPtr = true
+		case reflect.PointerTo(field.Type).Implements(tagValueType):
+		default:
+			return nil, amp.ErrCode_BadSchema.Errorf("BindCell: %s.%s has unsupported type %v", typ.Name(), field.Name, field.Type)
+		}
+		binding.fields = append(binding.fields, fb)
+	}
+	return binding, nil
+}
+
+func (binding *CellBinding) structOf(ptr any) (reflect.Value, error) {
+	val := reflect.ValueOf(ptr)
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+	if !val.IsValid() || val.Type() != binding.typ {
+		return reflect.Value{}, amp.ErrCode_BadSchema.Errorf("CellBinding: expected %v, got %T", binding.typ, ptr)
+	}
+	return val, nil
+}
+
+// Marshal writes each bound field of src, a pointer to a struct of the bound type, to the given CellWriter.
+func (binding *CellBinding) Marshal(w CellWriter, src any) error {
+	val, err := binding.structOf(src)
+	if err != nil {
+		return err
+	}
+
+	for _, fb := range binding.fields {
+		field := val.Field(fb.index)
+		if fb.omitEmpty && field.IsZero() {
+			continue
+		}
+
+		var item tag.Value
+		switch {
+		case fb.isText:
+			item = &amp.Tag{
+				Text: field.String(),
+			}
+		case fb.isPtr:
+			if field.IsNil() {
+				continue
+			}
+			item = field.Interface().(tag.Value)
+		default:
+			if !field.CanAddr() {
+				return amp.ErrCode_BadSchema.Errorf("CellBinding: %v must be marshalled via pointer", binding.typ)
+			}
+			item = field.Addr().Interface().(tag.Value)
+		}
+
+		if fb.SI.IsSet() {
+			w.PutItem(fb.SI, item)
+		} else {
+			w.Upsert(&amp.TxOp{
+				OpCode: amp.TxOpCode_UpsertElement,
+				AttrID: fb.attrID,
+			}, item)
+		}
+	}
+	return nil
+}
+
+// Unmarshal reads the attrs of the given cell in tx into the bound fields of dst, a pointer to a struct of the bound type.
+// Fields whose attr is not present in tx are left unchanged.
+func (binding *CellBinding) Unmarshal(tx *amp.TxMsg, cellID tag.ID, dst any) error {
+	val, err := binding.structOf(dst)
+	if err != nil {
+		return err
+	}
+	if !val.CanSet() {
+		return amp.ErrCode_BadSchema.Errorf("CellBinding: %v must be unmarshalled via pointer", binding.typ)
+	}
+
+	for _, fb := range binding.fields {
+		field := val.Field(fb.index)
+
+		var item tag.Value
+		switch {
+		case fb.isText:
+			item = &amp.Tag{}
+		case fb.isPtr:
+			item = reflect.New(field.Type().Elem()).Interface().(tag.Value)
+		default:
+			item = reflect.New(field.Type()).Interface().(tag.Value)
+		}
+
+		err := tx.Load(cellID, fb.attrID, fb.SI, item)
+		if err == amp.ErrPropertyNotFound {
+			continue
+		}
+		if err != nil {
+			return amp.ErrCode_BadValue.Errorf("CellBinding: %v.%s: %v", binding.typ.Name(), fb.name, err)
+		}
+
+		switch {
+		case fb.isText:
+			field.SetString(item.(*amp.Tag).Text)
+		case fb.isPtr:
+			field.Set(reflect.ValueOf(item))
+		default:
+			field.Set(reflect.ValueOf(item).Elem())
+		}
+	}
+	return nil
+}
+
+// MarshalStruct marshals the bound fields of src (a pointer to a struct) to the given CellWriter (see CellBinding).
+// This is typically called from a Cell's MarshalAttrs(), passing the cell itself.
+func MarshalStruct(w CellWriter, src any) error {
+	binding, err := BindCell(src)
+	if err != nil {
+		return err
+	}
+	return binding.Marshal(w, src)
+}
+
+// UnmarshalStruct unmarshals the attrs of the given cell in tx into the bound fields of dst (see CellBinding).
+func UnmarshalStruct(tx *amp.TxMsg, cellID tag.ID, dst any) error {
+	binding, err := BindCell(dst)
+	if err != nil {
+		return err
+	}
+	return binding.Unmarshal(tx, cellID, dst)
+}
@@ -0,0 +1,205 @@
+package std
+
+import (
+	"sort"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// childList is the ordered collection of a pin's children.
+//
+// Each child is assigned a rank such that ranks ascend with the child order, where a child's rank is pushed as the
+// Tag.Ordering of its link (see CellChildren).  A child inserted or moved is ranked between its neighbors so that
+// the ranks of its siblings are unchanged, unless float32 precision is exhausted and all children are re-ranked.
+type childList[AppT amp.AppInstance] struct {
+	entries []*childEntry[AppT] // ordered by SortKey and then by seq
+	byID    map[tag.ID]*childEntry[AppT]
+	nextSeq uint64
+}
+
+type childEntry[AppT amp.AppInstance] struct {
+	cell Cell[AppT]
+	id   tag.ID
+	key  SortKey // key when inserted (see Pin.MoveChild)
+	seq  uint64  // insertion order
+	rank float32 // position pushed to the client
+}
+
+func sortKeyOf[AppT amp.AppInstance](cell Cell[AppT]) SortKey {
+	if ordered, ok := cell.(OrderedCell); ok {
+		return ordered.SortKey()
+	}
+	return SortKey{}
+}
+
+// Compare returns -1, 0, or 1 if key orders before, the same as, or after other.
+func (key SortKey) Compare(other SortKey) int {
+	switch {
+	case key.Ordering < other.Ordering:
+		return -1
+	case key.Ordering > other.Ordering:
+		return 1
+	case key.Label < other.Label:
+		return -1
+	case key.Label > other.Label:
+		return 1
+	}
+	return key.TimeCreated.Compare(other.TimeCreated)
+}
+
+func (entry *childEntry[AppT]) before(other *childEntry[AppT]) bool {
+	if c := entry.key.Compare(other.key); c != 0 {
+		return c < 0
+	}
+	return entry.seq < other.seq
+}
+
+// insert adds the given entry in order.
+// Siblings re-ranked as a result are pushed on the next relink since their rank no longer matches the rank linked.
+func (list *childList[AppT]) insert(entry *childEntry[AppT]) {
+	if list.byID == nil {
+		list.byID = make(map[tag.ID]*childEntry[AppT])
+	}
+	list.byID[entry.id] = entry
+
+	i := sort.Search(len(list.entries), func(j int) bool {
+		return entry.before(list.entries[j])
+	})
+	list.entries = append(list.entries, nil)
+	copy(list.entries[i+1:], list.entries[i:])
+	list.entries[i] = entry
+
+	n := len(list.entries)
+	switch {
+	case n == 1:
+		entry.rank = 1
+	case i == 0:
+		entry.rank = list.entries[1].rank - 1
+	case i == n-1:
+		entry.rank = list.entries[i-1].rank + 1
+	default:
+		prev, next := list.entries[i-1].rank, list.entries[i+1].rank
+		entry.rank = prev + (next-prev)/2
+		if entry.rank <= prev || entry.rank >= next {
+			for j, entry := range list.entries {
+				entry.rank = float32(j + 1)
+			}
+		}
+	}
+}
+
+// remove removes the entry having the given ID, returning nil if not found.
+func (list *childList[AppT]) remove(childID tag.ID) *childEntry[AppT] {
+	entry := list.byID[childID]
+	if entry == nil {
+		return nil
+	}
+	delete(list.byID, childID)
+
+	i := sort.Search(len(list.entries), func(j int) bool {
+		return !list.entries[j].before(entry)
+	})
+	list.entries = append(list.entries[:i], list.entries[i+1:]...)
+	return entry
+}
+
+// AddChild adds a child cell to this pin, ordered by its SortKey and then after children previously added.
+// If this pin is maintained, the child is pushed on the next update.  Concurrency safe.
+func (pin *Pin[AppT]) AddChild(sub Cell[AppT]) {
+	child := sub.Root()
+	if child.ID.IsNil() {
+		child.ID = tag.Now()
+	}
+	childID := child.ID
+
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	if prev := pin.children.remove(childID); prev != nil && prev.cell.Root() != child {
+		prev.cell.Root().removePin(pin)
+	}
+	entry := &childEntry[AppT]{
+		cell: sub,
+		id:   childID,
+		key:  sortKeyOf(sub),
+		seq:  pin.children.nextSeq,
+	}
+	pin.children.nextSeq++
+	pin.children.insert(entry)
+	if pin.maintaining {
+		child.addPin(pin)
+	}
+	pin.relinkLocked()
+}
+
+// MoveChild repositions a child within this pin's children, typically after its SortKey has changed.
+// If this pin is maintained, only the link of the moved child is pushed on the next update.  Concurrency safe.
+func (pin *Pin[AppT]) MoveChild(childID tag.ID) {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	if entry := pin.children.remove(childID); entry != nil {
+		entry.key = sortKeyOf(entry.cell)
+		pin.children.insert(entry)
+		pin.relinkLocked()
+	}
+}
+
+// RemoveChild removes a child from this pin.
+// If this pin is maintained, the link of the removed child is deleted on the next update.  Concurrency safe.
+func (pin *Pin[AppT]) RemoveChild(childID tag.ID) {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	if entry := pin.children.remove(childID); entry != nil {
+		entry.cell.Root().removePin(pin)
+		pin.relinkLocked()
+	}
+}
+
+// GetCell returns the pinned cell or the child having the given ID, or nil if not found.  Concurrency safe.
+func (pin *Pin[AppT]) GetCell(target tag.ID) Cell[AppT] {
+	if target == pin.Cell.Root().ID {
+		return pin.Cell
+	}
+
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	if entry := pin.children.byID[target]; entry != nil {
+		return entry.cell
+	}
+	return nil
+}
+
+// relinkLocked marks the links of this pin's children to be pushed on the next update.
+// Called while pin.mu is locked.
+func (pin *Pin[AppT]) relinkLocked() {
+	pin.relink = true
+	select {
+	case pin.notify <- struct{}{}:
+	default:
+	}
+}
+
+// setMaintaining registers (or unregisters) this pin with its cells so that edits committed via other pins are
+// pushed (see CellNode.NotifyPins).
+func (pin *Pin[AppT]) setMaintaining(maintaining bool) {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	pin.maintaining = maintaining
+	nodes := make([]*CellNode[AppT], 0, 1+len(pin.children.entries))
+	nodes = append(nodes, pin.Cell.Root())
+	for _, entry := range pin.children.entries {
+		nodes = append(nodes, entry.cell.Root())
+	}
+	for _, node := range nodes {
+		if maintaining {
+			node.addPin(pin)
+		} else {
+			node.removePin(pin)
+		}
+	}
+}
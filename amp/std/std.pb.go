@@ -723,13 +723,12 @@ func (m *Placement) GetMetric() amp.Metric {
 	return amp.Metric_Nil
 }
 
+// The U.S.C Social Experiment
+// - people "vote" on what they want
+// - we're making a voting os app -- invoke blockchains
 //
-//The U.S.C Social Experiment
-//- people "vote" on what they want
-//- we're making a voting os app -- invoke blockchains
-//
-//-- there were penned as  channels, but the actual pb declarations are update elements.
-//-- suffix ideas: Rev, Delta, Op, Tx, Item, Entry, Edit
+// -- there were penned as  channels, but the actual pb declarations are update elements.
+// -- suffix ideas: Rev, Delta, Op, Tx, Item, Entry, Edit
 type Ballot struct {
 }
 
@@ -800,7 +799,7 @@ func (m *NotesEntry) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NotesEntry proto.InternalMessageInfo
 
-//  "amp.tag.spec.talk.spec.message"
+// "amp.tag.spec.talk.spec.message"
 type ChatEntry struct {
 }
 
@@ -1194,79 +1193,80 @@ func init() {
 func init() { proto.RegisterFile("amp/std/std.proto", fileDescriptor_b6f70fdd671fe185) }
 
 var fileDescriptor_b6f70fdd671fe185 = []byte{
-	// 1139 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0xbf, 0x73, 0x13, 0x47,
-	0x14, 0xc7, 0xb5, 0x92, 0x7d, 0x92, 0x9e, 0x0d, 0x1c, 0x8b, 0x81, 0x85, 0x30, 0x37, 0x9a, 0x4b,
-	0x66, 0x62, 0x9c, 0xc1, 0x58, 0x27, 0x92, 0x49, 0x93, 0x64, 0xfc, 0x0b, 0xd0, 0x10, 0x83, 0xd8,
-	0x13, 0x04, 0x68, 0x3c, 0x6b, 0xdd, 0x22, 0xef, 0x70, 0xba, 0x53, 0xee, 0x56, 0x0c, 0xa6, 0xca,
-	0x9f, 0x90, 0x26, 0x75, 0xda, 0x0c, 0x7d, 0xba, 0xf4, 0x49, 0x49, 0x49, 0x91, 0x22, 0x88, 0x26,
-	0x25, 0x7f, 0x40, 0x8a, 0xcc, 0xbe, 0xfb, 0x21, 0xd9, 0x99, 0x74, 0x14, 0x8c, 0xf6, 0xfb, 0xf9,
-	0x2e, 0xfb, 0xf6, 0xed, 0x7b, 0xbb, 0x3e, 0x38, 0x2b, 0x46, 0xe3, 0xeb, 0xa9, 0x0e, 0xcc, 0xbf,
-	0xf5, 0x71, 0x12, 0xeb, 0x98, 0xd6, 0x52, 0x1d, 0x5c, 0x3e, 0x65, 0xb8, 0x18, 0x8d, 0x33, 0xe6,
-	0x7e, 0x0f, 0x8d, 0x5e, 0x9c, 0x2a, 0xad, 0xe2, 0x88, 0x5e, 0x85, 0xc6, 0x76, 0x9c, 0x04, 0xfd,
-	0xa3, 0xb1, 0x64, 0xa4, 0x45, 0x56, 0x4f, 0x7b, 0xa7, 0xd6, 0xcd, 0xff, 0x2e, 0x20, 0x2f, 0x6d,
-	0xba, 0x0c, 0xe4, 0x3e, 0xab, 0xb5, 0xc8, 0x2a, 0xe1, 0xe4, 0xbe, 0x51, 0x9c, 0x2d, 0x64, 0x8a,
-	0x1b, 0xe5, 0xb3, 0xc5, 0x4c, 0xf9, 0xd4, 0x86, 0x1a, 0xbf, 0xf7, 0x80, 0x59, 0x2d, 0xb2, 0x5a,
-	0xe5, 0x66, 0xe8, 0xfe, 0x4c, 0xa0, 0xc1, 0xe5, 0x73, 0x95, 0x9a, 0x98, 0x17, 0xa1, 0xde, 0x17,
-	0xc3, 0xee, 0xce, 0xfe, 0x06, 0xab, 0xb6, 0xc8, 0x6a, 0x8d, 0x5b, 0x28, 0x37, 0x66, 0x46, 0x1b,
-	0xe3, 0x58, 0xb9, 0xd1, 0x9e, 0x19, 0x1e, 0x86, 0x2c, 0x0c, 0x8f, 0x32, 0xa8, 0xdf, 0x52, 0xfa,
-	0xb6, 0x48, 0x0f, 0x31, 0x7a, 0x93, 0x17, 0xd2, 0xec, 0xc1, 0xbf, 0x93, 0xed, 0xa1, 0xc9, 0xcd,
-	0x90, 0x5e, 0x81, 0x66, 0x5f, 0x8d, 0x64, 0xaa, 0xc5, 0x68, 0xcc, 0xea, 0x18, 0x78, 0x06, 0xdc,
-	0x3e, 0xd4, 0x8d, 0xe8, 0x8b, 0xe1, 0x07, 0xdc, 0x9f, 0xfb, 0x27, 0x01, 0xeb, 0xa6, 0xdf, 0x8d,
-	0x9e, 0xc6, 0x94, 0xc2, 0xc2, 0x5e, 0x1c, 0x64, 0xa7, 0xdc, 0xe4, 0x38, 0xa6, 0x2b, 0xb0, 0xd8,
-	0x4d, 0x77, 0x54, 0x82, 0x71, 0x1a, 0x3c, 0x13, 0x66, 0xe6, 0x5d, 0x31, 0x92, 0x18, 0xa3, 0xc9,
-	0x71, 0x6c, 0x12, 0x35, 0xbf, 0xdf, 0xca, 0x08, 0x23, 0x2c, 0xf2, 0x42, 0xd2, 0x16, 0x2c, 0x6d,
-	0xc7, 0x91, 0x96, 0x91, 0xc6, 0x22, 0x66, 0xc7, 0x30, 0x8f, 0x4c, 0xe2, 0xdb, 0x89, 0x14, 0x5a,
-	0x06, 0x9b, 0xba, 0x48, 0xbc, 0x04, 0xd4, 0x01, 0xd8, 0x8b, 0x03, 0xf5, 0x54, 0xa1, 0xdd, 0x40,
-	0x7b, 0x8e, 0xd0, 0xcb, 0xd0, 0xd8, 0x3a, 0xd2, 0xd2, 0x57, 0x2f, 0x25, 0x6b, 0xa2, 0x5b, 0x6a,
-	0xf7, 0x77, 0x02, 0xf5, 0xed, 0x43, 0x11, 0x45, 0x32, 0xfc, 0x90, 0x55, 0xbd, 0x04, 0x0d, 0x7f,
-	0x2c, 0x07, 0xb8, 0x96, 0x85, 0x6b, 0xd5, 0x33, 0xbd, 0x31, 0x67, 0xb5, 0x31, 0x15, 0xab, 0xb0,
-	0xda, 0x73, 0x96, 0x87, 0x69, 0x94, 0x96, 0x47, 0x5b, 0xb0, 0xe0, 0x4f, 0x0e, 0x52, 0xb6, 0xdc,
-	0xaa, 0xad, 0x2e, 0x79, 0xcb, 0x59, 0x87, 0x67, 0xfb, 0xe6, 0xe8, 0xb8, 0x00, 0x8d, 0x5e, 0x28,
-	0x8e, 0xc4, 0x41, 0x28, 0xdd, 0x06, 0x58, 0x9b, 0xcf, 0x85, 0x16, 0x89, 0xfb, 0x0f, 0x81, 0x66,
-	0x2f, 0x14, 0x03, 0x39, 0x92, 0x91, 0x36, 0x75, 0xe9, 0xc5, 0xe9, 0x06, 0x56, 0x90, 0x70, 0x1c,
-	0xe7, 0xac, 0x8d, 0x29, 0x67, 0xac, 0x9d, 0x33, 0x2f, 0xbf, 0x2b, 0x38, 0xa6, 0x17, 0xc0, 0xf2,
-	0x07, 0x22, 0x94, 0x1b, 0x98, 0x6a, 0x95, 0xe7, 0xaa, 0xe4, 0x6d, 0x2c, 0x5c, 0xc1, 0xdb, 0x25,
-	0xf7, 0xf2, 0x5b, 0x94, 0x2b, 0xc3, 0x77, 0x27, 0xa1, 0x4c, 0x1e, 0x61, 0xf6, 0x55, 0x9e, 0xab,
-	0x92, 0x3f, 0xc6, 0xd4, 0x0b, 0xfe, 0xb8, 0xe4, 0x4f, 0xb0, 0x76, 0x05, 0x7f, 0x42, 0x3f, 0x06,
-	0x6b, 0x4f, 0xea, 0x44, 0x0d, 0xd8, 0x32, 0xde, 0xfa, 0xa5, 0x75, 0xf3, 0x3e, 0x64, 0x88, 0xe7,
-	0x96, 0x39, 0x88, 0x2d, 0x11, 0x86, 0xb1, 0x76, 0x97, 0x01, 0xee, 0xc6, 0x5a, 0xa6, 0xbb, 0x91,
-	0x4e, 0x8e, 0xdc, 0x25, 0x68, 0x6e, 0x1f, 0x0a, 0x9d, 0x09, 0x0a, 0xb6, 0x3f, 0x4e, 0xa4, 0x08,
-	0xd2, 0x43, 0x29, 0x73, 0xf6, 0x10, 0x60, 0x4b, 0x04, 0x43, 0xb9, 0xa3, 0x86, 0x4a, 0x9b, 0xfe,
-	0xdb, 0x1c, 0x8d, 0x43, 0xa5, 0x27, 0x79, 0xfb, 0xd7, 0xf8, 0x0c, 0xd0, 0x35, 0xb0, 0x4b, 0xb1,
-	0x17, 0x07, 0x93, 0x70, 0x92, 0xe6, 0x0d, 0xf4, 0x1f, 0xee, 0x76, 0xa0, 0xd1, 0x17, 0x43, 0x5c,
-	0x9a, 0x7e, 0x0a, 0x56, 0x60, 0x96, 0x4f, 0x19, 0xc1, 0xaa, 0x9e, 0xc1, 0xaa, 0xce, 0xc2, 0xf2,
-	0xdc, 0x76, 0x7f, 0xad, 0x42, 0xad, 0xcf, 0x7d, 0x7a, 0x1a, 0xaa, 0x8f, 0xda, 0xec, 0x2a, 0x16,
-	0xa5, 0xfa, 0xa8, 0x8d, 0xda, 0x63, 0x6b, 0xb9, 0xf6, 0x50, 0x77, 0xd8, 0x67, 0xb9, 0xee, 0xd0,
-	0x2f, 0xa0, 0x89, 0x87, 0x8e, 0xb7, 0xd6, 0xc3, 0x53, 0x62, 0x18, 0xa3, 0xcf, 0xfd, 0xf5, 0x87,
-	0x2a, 0x9d, 0x88, 0xb0, 0xf4, 0xf9, 0x6c, 0xea, 0x5c, 0x49, 0x3b, 0xff, 0x53, 0xd2, 0x1b, 0x27,
-	0x4b, 0x8a, 0xa3, 0x0e, 0xfb, 0x7c, 0x8e, 0x77, 0xcc, 0x95, 0xe7, 0xb1, 0x16, 0x5a, 0xb6, 0xd9,
-	0x57, 0x68, 0x14, 0x72, 0xe6, 0x78, 0xec, 0xeb, 0x79, 0xc7, 0x9b, 0x39, 0x1d, 0xf6, 0xcd, 0xbc,
-	0xd3, 0x71, 0x37, 0xe0, 0xcc, 0x89, 0x3d, 0xd3, 0x53, 0xd0, 0xdc, 0x9c, 0xe8, 0x18, 0x81, 0x5d,
-	0xa1, 0xa7, 0x01, 0x6e, 0xaa, 0x17, 0x32, 0xc8, 0x34, 0x71, 0x7f, 0x22, 0xb0, 0xb4, 0x23, 0xb4,
-	0xf0, 0xe5, 0x10, 0xdb, 0x9f, 0x41, 0xdd, 0x5c, 0xfc, 0x7b, 0x4f, 0x53, 0xec, 0xd5, 0x05, 0x5e,
-	0x48, 0x93, 0x01, 0x3e, 0x09, 0x2f, 0xb1, 0x59, 0x17, 0x78, 0xae, 0xcc, 0xd3, 0xd2, 0x8d, 0x42,
-	0x15, 0x49, 0xb3, 0x0c, 0x36, 0xec, 0x32, 0x9f, 0x23, 0xa6, 0x31, 0x7c, 0x9d, 0x48, 0x31, 0x7a,
-	0xc0, 0xbb, 0xd8, 0x9f, 0x4d, 0x3e, 0x03, 0xb8, 0x6a, 0x18, 0x1f, 0x74, 0x77, 0x18, 0x64, 0xef,
-	0x49, 0xa6, 0xd6, 0x5e, 0x91, 0xd9, 0xdf, 0x2c, 0xca, 0x60, 0xa5, 0x18, 0xef, 0x3f, 0x88, 0xd2,
-	0xb1, 0x1c, 0xe0, 0xbb, 0x65, 0x57, 0xe8, 0x0a, 0xd8, 0xa5, 0x73, 0x2f, 0x09, 0x64, 0x22, 0x03,
-	0x9b, 0xd0, 0x2b, 0xc0, 0x4a, 0xda, 0x0b, 0x45, 0x24, 0xf7, 0xb7, 0x45, 0xa2, 0x65, 0xaa, 0x44,
-	0x64, 0x2f, 0xd2, 0x8f, 0xe0, 0xe2, 0x09, 0xf7, 0xb6, 0x7c, 0xb1, 0xfb, 0x5c, 0x46, 0xdc, 0xb6,
-	0xe8, 0x25, 0x38, 0x5f, 0x9a, 0xb7, 0x64, 0xac, 0x82, 0x7d, 0x7f, 0x7c, 0x28, 0x13, 0x69, 0xc3,
-	0xb1, 0x5d, 0x64, 0xd6, 0x77, 0xb7, 0xfc, 0x2f, 0x6f, 0xd8, 0x4b, 0x6b, 0xbf, 0x99, 0xcd, 0xca,
-	0x30, 0xbc, 0xa3, 0xa2, 0x80, 0x9e, 0x83, 0x33, 0xc5, 0x78, 0xdf, 0xd7, 0x42, 0xab, 0x81, 0x5d,
-	0xa1, 0x17, 0x80, 0x96, 0x70, 0x37, 0x50, 0xfa, 0xa6, 0x92, 0x61, 0x60, 0xdb, 0xc7, 0x26, 0x6f,
-	0x4d, 0xb4, 0x8e, 0x23, 0xfb, 0x2c, 0xbd, 0x08, 0xe7, 0x4a, 0xd8, 0xd5, 0x72, 0xd4, 0x53, 0x83,
-	0x67, 0x32, 0xb1, 0xe9, 0xb1, 0xd9, 0xfd, 0x78, 0x38, 0x0c, 0xa5, 0x7d, 0xee, 0x78, 0xbc, 0x50,
-	0x05, 0x32, 0xb1, 0x57, 0xe8, 0x79, 0x38, 0x5b, 0xc2, 0x5e, 0x12, 0x0f, 0x13, 0x99, 0xa6, 0xf6,
-	0x79, 0x3c, 0xae, 0x72, 0xee, 0x58, 0x45, 0x91, 0x4c, 0xec, 0x0b, 0x5b, 0xc1, 0xeb, 0xb7, 0x4e,
-	0xe5, 0xcd, 0x5b, 0xa7, 0xf2, 0xfe, 0xad, 0x43, 0x7e, 0x98, 0x3a, 0xe4, 0x97, 0xa9, 0x43, 0xfe,
-	0x98, 0x3a, 0xe4, 0xf5, 0xd4, 0x21, 0x7f, 0x4d, 0x1d, 0xf2, 0xf7, 0xd4, 0xa9, 0xbc, 0x9f, 0x3a,
-	0xe4, 0xc7, 0x77, 0x4e, 0xe5, 0xf5, 0x3b, 0xa7, 0xf2, 0xe6, 0x9d, 0x53, 0x79, 0xf2, 0xc9, 0x50,
-	0xe9, 0xc3, 0xc9, 0xc1, 0xfa, 0x20, 0x1e, 0x99, 0xcf, 0x8e, 0x6b, 0x9d, 0x00, 0x7f, 0xd2, 0xe0,
-	0xd9, 0xb5, 0x61, 0x7c, 0x3d, 0xff, 0x48, 0x79, 0x55, 0xad, 0x6f, 0xee, 0xf5, 0xd6, 0x7d, 0x1d,
-	0x1c, 0x58, 0xf8, 0x5d, 0xd2, 0xf9, 0x37, 0x00, 0x00, 0xff, 0xff, 0x13, 0x69, 0x05, 0x35, 0xc0,
-	0x08, 0x00, 0x00,
+	// 1163 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x8f, 0xd3, 0x46,
+	0x14, 0xcf, 0x24, 0xbb, 0x8e, 0xfd, 0x12, 0xc0, 0x0c, 0x0b, 0x0c, 0x14, 0x59, 0x91, 0x5b, 0xa9,
+	0xcb, 0x56, 0x2c, 0x1b, 0x87, 0x56, 0x15, 0x52, 0x5b, 0xed, 0x3f, 0x20, 0xa2, 0x0b, 0x61, 0x1c,
+	0x28, 0x70, 0x59, 0xcd, 0xc6, 0x43, 0x32, 0xc2, 0xb1, 0x23, 0x7b, 0x82, 0x58, 0x2e, 0xed, 0x47,
+	0xe8, 0xa5, 0x5f, 0x80, 0x53, 0x85, 0xd4, 0x63, 0x6f, 0xbd, 0xb7, 0x37, 0x38, 0x72, 0x2c, 0xe1,
+	0xc2, 0x91, 0x0f, 0xd0, 0x43, 0x35, 0x63, 0xc7, 0xf1, 0x52, 0xf5, 0xc6, 0x61, 0xe5, 0xf7, 0xfb,
+	0xfd, 0x66, 0xdf, 0x7b, 0xf3, 0xde, 0x9b, 0xc9, 0xc0, 0x49, 0x36, 0x9e, 0x5c, 0x4e, 0x65, 0xa0,
+	0xfe, 0xd6, 0x27, 0x49, 0x2c, 0x63, 0x5c, 0x4b, 0x65, 0x70, 0xfe, 0x98, 0xe2, 0xd9, 0x78, 0x92,
+	0x71, 0xee, 0x8f, 0x60, 0xf6, 0xe2, 0x54, 0x48, 0x11, 0x47, 0xf8, 0x22, 0x98, 0xdb, 0x71, 0x12,
+	0xf4, 0x0f, 0x27, 0x9c, 0xa0, 0x16, 0x5a, 0x3d, 0xee, 0x1d, 0x5b, 0x57, 0xff, 0x3d, 0x27, 0x69,
+	0x21, 0xe3, 0x26, 0xa0, 0x3b, 0xa4, 0xd6, 0x42, 0xab, 0x88, 0xa2, 0x3b, 0x0a, 0x51, 0xb2, 0x94,
+	0x21, 0xaa, 0x90, 0x4f, 0x96, 0x33, 0xe4, 0x63, 0x1b, 0x6a, 0xf4, 0xf6, 0x5d, 0x62, 0xb4, 0xd0,
+	0x6a, 0x95, 0x2a, 0xf3, 0x6a, 0xf3, 0xf9, 0x4b, 0x52, 0x04, 0x75, 0x7f, 0x43, 0x60, 0x52, 0xfe,
+	0x44, 0xa4, 0x2a, 0x83, 0xb3, 0x50, 0xef, 0xb3, 0x61, 0x77, 0x67, 0x7f, 0x83, 0x54, 0x5b, 0x68,
+	0xb5, 0x46, 0x0d, 0x0d, 0x37, 0x16, 0x42, 0x5b, 0x47, 0x35, 0x72, 0xa1, 0xbd, 0x10, 0x3c, 0xb2,
+	0x54, 0x12, 0x3c, 0x4c, 0xa0, 0x7e, 0x5d, 0xc8, 0x1b, 0x2c, 0x1d, 0xe9, 0x5c, 0x2c, 0x3a, 0x87,
+	0x2a, 0x23, 0xff, 0x66, 0x96, 0x91, 0x45, 0x95, 0x89, 0x2f, 0x80, 0xd5, 0x17, 0x63, 0x9e, 0x4a,
+	0x36, 0x9e, 0x90, 0xba, 0x0e, 0xbc, 0x20, 0xb2, 0x7c, 0xe7, 0x29, 0xba, 0x0c, 0xea, 0x4a, 0xea,
+	0xb3, 0xe1, 0x47, 0xcc, 0xf6, 0x6a, 0xe3, 0xf9, 0x4b, 0x32, 0xf7, 0xeb, 0xbe, 0x43, 0x60, 0x5c,
+	0xf3, 0xbb, 0xd1, 0xa3, 0x18, 0x63, 0x58, 0xda, 0x8b, 0x83, 0xac, 0x1d, 0x16, 0xd5, 0x36, 0x5e,
+	0x81, 0xe5, 0x6e, 0xba, 0x23, 0x12, 0x1d, 0xd4, 0xa4, 0x19, 0x50, 0x2b, 0x6f, 0xb1, 0x31, 0xd7,
+	0x01, 0x2d, 0xaa, 0x6d, 0x55, 0x03, 0xf5, 0xfd, 0x9e, 0x47, 0x3a, 0xdc, 0x32, 0x9d, 0x43, 0xdc,
+	0x82, 0xc6, 0x76, 0x1c, 0x49, 0x1e, 0x49, 0xdd, 0xed, 0xac, 0x42, 0x65, 0x4a, 0xd5, 0x64, 0x3b,
+	0xe1, 0x4c, 0xf2, 0x60, 0x53, 0xce, 0x6b, 0x52, 0x10, 0xd8, 0x01, 0xd8, 0x8b, 0x03, 0xf1, 0x48,
+	0x68, 0xd9, 0xd4, 0x72, 0x89, 0xc1, 0xe7, 0xc1, 0xdc, 0x3a, 0x94, 0xdc, 0x17, 0xcf, 0x38, 0xb1,
+	0xb4, 0x5a, 0xe0, 0xab, 0xf0, 0xfc, 0x25, 0xc9, 0xf7, 0xe7, 0xfe, 0x89, 0xa0, 0xbe, 0x3d, 0x62,
+	0x51, 0xc4, 0xc3, 0x8f, 0xd9, 0xfc, 0x73, 0x60, 0xfa, 0x13, 0x3e, 0xd0, 0xbe, 0x0c, 0xed, 0xab,
+	0x9e, 0xe1, 0x8d, 0x92, 0xd4, 0xd6, 0xdb, 0x32, 0xe6, 0x52, 0xbb, 0x24, 0x79, 0xc4, 0x2c, 0x4b,
+	0x1e, 0x6e, 0xc1, 0x92, 0x3f, 0x3d, 0x48, 0x49, 0xb3, 0x55, 0x5b, 0x6d, 0x78, 0xcd, 0xec, 0x58,
+	0x64, 0x79, 0x53, 0xad, 0xb8, 0x00, 0x66, 0x2f, 0x64, 0x87, 0xec, 0x20, 0xe4, 0xae, 0x09, 0xc6,
+	0xe6, 0x13, 0x26, 0x59, 0xe2, 0xfe, 0x83, 0xc0, 0xea, 0x85, 0x6c, 0xc0, 0xc7, 0x3c, 0x92, 0xaa,
+	0x47, 0xbd, 0x38, 0xdd, 0xd0, 0xdd, 0x44, 0x54, 0xdb, 0x39, 0xd7, 0x26, 0xd5, 0x82, 0x6b, 0xe7,
+	0x9c, 0x97, 0x1f, 0x30, 0x6d, 0xe3, 0x33, 0x60, 0xf8, 0x03, 0x16, 0xf2, 0x0d, 0xbd, 0xd5, 0x2a,
+	0xcd, 0x51, 0xc1, 0xb7, 0xc9, 0x72, 0x89, 0x6f, 0x17, 0xbc, 0x97, 0x1f, 0xbd, 0x1c, 0x29, 0x7e,
+	0x77, 0x1a, 0xf2, 0xe4, 0xbe, 0xde, 0x7d, 0x95, 0xe6, 0xa8, 0xe0, 0x1f, 0x10, 0xb3, 0xc4, 0x3f,
+	0x28, 0xf8, 0x87, 0xc4, 0x2a, 0xf1, 0x0f, 0xf1, 0xa7, 0x60, 0xec, 0x71, 0x99, 0x88, 0x01, 0x69,
+	0xea, 0xab, 0xa2, 0xb1, 0xae, 0x2e, 0x95, 0x8c, 0xa2, 0xb9, 0xa4, 0x0a, 0xb1, 0xc5, 0xc2, 0x30,
+	0x96, 0x6e, 0x13, 0xe0, 0x56, 0x2c, 0x79, 0xba, 0x1b, 0xc9, 0xe4, 0xd0, 0x6d, 0x80, 0xb5, 0x3d,
+	0x62, 0x32, 0x03, 0x18, 0x6c, 0x7f, 0x92, 0x70, 0x16, 0xa4, 0x23, 0xce, 0x73, 0xee, 0x1e, 0xc0,
+	0x16, 0x0b, 0x86, 0x7c, 0x47, 0x0c, 0x85, 0x54, 0xb3, 0xb8, 0x39, 0x9e, 0x84, 0x42, 0x4e, 0xf3,
+	0xa3, 0x50, 0xa3, 0x0b, 0x02, 0xaf, 0x81, 0x5d, 0x80, 0xbd, 0x38, 0x98, 0x86, 0xd3, 0x34, 0x1f,
+	0xa0, 0xff, 0xf0, 0x6e, 0x07, 0xcc, 0x3e, 0x1b, 0x6a, 0xd7, 0xf8, 0x73, 0x30, 0x02, 0xe5, 0x3e,
+	0x25, 0x48, 0x77, 0xf5, 0x84, 0xee, 0xea, 0x22, 0x2c, 0xcd, 0x65, 0xf7, 0xf7, 0x2a, 0xd4, 0xfa,
+	0xd4, 0xc7, 0xc7, 0xa1, 0x7a, 0xbf, 0x4d, 0x2e, 0xea, 0xa6, 0x54, 0xef, 0xb7, 0x35, 0xf6, 0xc8,
+	0x5a, 0x8e, 0x3d, 0x8d, 0x3b, 0xe4, 0x8b, 0x1c, 0x77, 0xf0, 0x57, 0x60, 0xe9, 0xa2, 0xeb, 0x13,
+	0xec, 0xe9, 0x2a, 0x11, 0x1d, 0xa3, 0x4f, 0xfd, 0xf5, 0x7b, 0x22, 0x9d, 0xb2, 0xb0, 0xd0, 0xe9,
+	0x62, 0x69, 0xa9, 0xa5, 0x9d, 0xff, 0x69, 0xe9, 0x95, 0x0f, 0x5b, 0xaa, 0xad, 0x0e, 0xf9, 0xb2,
+	0xc4, 0x77, 0xd4, 0xf1, 0xa7, 0xb1, 0x64, 0x92, 0xb7, 0xc9, 0x37, 0x5a, 0x98, 0xc3, 0x85, 0xe2,
+	0x91, 0x6f, 0xcb, 0x8a, 0xb7, 0x50, 0x3a, 0xe4, 0xbb, 0xb2, 0xd2, 0x71, 0x37, 0xe0, 0xc4, 0x07,
+	0x39, 0xe3, 0x63, 0x60, 0x6d, 0x4e, 0x65, 0xac, 0x09, 0xbb, 0x82, 0x8f, 0x03, 0x5c, 0x13, 0x4f,
+	0x79, 0x90, 0x61, 0xe4, 0xfe, 0x82, 0xa0, 0xb1, 0xc3, 0x24, 0xf3, 0xf9, 0x50, 0x8f, 0x3f, 0x81,
+	0xba, 0xba, 0x04, 0x6e, 0x3f, 0x4a, 0xf5, 0xac, 0x2e, 0xd1, 0x39, 0x54, 0x3b, 0x50, 0xa6, 0xff,
+	0x4c, 0x0f, 0xeb, 0x12, 0xcd, 0x91, 0xba, 0x66, 0xba, 0x51, 0x28, 0x22, 0xae, 0xdc, 0xe8, 0x81,
+	0x6d, 0xd2, 0x12, 0xa3, 0x06, 0xc3, 0x97, 0x09, 0x67, 0xe3, 0xbb, 0xb4, 0xab, 0xe7, 0xd3, 0xa2,
+	0x0b, 0x42, 0x7b, 0x0d, 0xe3, 0x83, 0xee, 0x0e, 0x81, 0xec, 0x3e, 0xc9, 0xd0, 0xda, 0x0b, 0xb4,
+	0xf8, 0xa1, 0xc3, 0x04, 0x56, 0xe6, 0xf6, 0xfe, 0xdd, 0x28, 0x9d, 0xf0, 0x81, 0xbe, 0xc3, 0xec,
+	0x0a, 0x5e, 0x01, 0xbb, 0x50, 0x6e, 0x27, 0x01, 0x4f, 0x78, 0x60, 0x23, 0x7c, 0x01, 0x48, 0xc1,
+	0xf6, 0x42, 0x16, 0xf1, 0xfd, 0x6d, 0x96, 0x48, 0x9e, 0x0a, 0x16, 0xd9, 0xcb, 0xf8, 0x13, 0x38,
+	0xfb, 0x81, 0x7a, 0x83, 0x3f, 0xdd, 0x7d, 0xc2, 0x23, 0x6a, 0x1b, 0xf8, 0x1c, 0x9c, 0x2e, 0xc4,
+	0xeb, 0x3c, 0x16, 0xc1, 0xbe, 0x3f, 0x19, 0xf1, 0x84, 0xdb, 0x70, 0x24, 0x8b, 0x4c, 0xfa, 0xe1,
+	0xba, 0xff, 0xf5, 0x15, 0xbb, 0xb1, 0xf6, 0x87, 0x4a, 0x96, 0x87, 0xe1, 0x4d, 0x11, 0x05, 0xf8,
+	0x14, 0x9c, 0x98, 0xdb, 0xfb, 0xbe, 0x64, 0x52, 0x0c, 0xec, 0x0a, 0x3e, 0x03, 0xb8, 0x20, 0x77,
+	0x03, 0x21, 0xaf, 0x09, 0x1e, 0x06, 0xb6, 0x7d, 0x64, 0xf1, 0xd6, 0x54, 0xca, 0x38, 0xb2, 0x4f,
+	0xe2, 0xb3, 0x70, 0xaa, 0x20, 0xbb, 0x92, 0x8f, 0x7b, 0x62, 0xf0, 0x98, 0x27, 0x36, 0x3e, 0xb2,
+	0xba, 0x1f, 0x0f, 0x87, 0x21, 0xb7, 0x4f, 0x1d, 0x8d, 0x17, 0x8a, 0x80, 0x27, 0xf6, 0x0a, 0x3e,
+	0x0d, 0x27, 0x0b, 0xb2, 0x97, 0xc4, 0xc3, 0x84, 0xa7, 0xa9, 0x7d, 0x5a, 0x97, 0xab, 0x58, 0x3b,
+	0x11, 0x51, 0xc4, 0x13, 0xfb, 0xcc, 0x56, 0xf0, 0xea, 0x8d, 0x53, 0x79, 0xfd, 0xc6, 0xa9, 0xbc,
+	0x7f, 0xe3, 0xa0, 0x9f, 0x66, 0x0e, 0xfa, 0x75, 0xe6, 0xa0, 0xbf, 0x66, 0x0e, 0x7a, 0x35, 0x73,
+	0xd0, 0xdf, 0x33, 0x07, 0xbd, 0x9b, 0x39, 0x95, 0xf7, 0x33, 0x07, 0xfd, 0xfc, 0xd6, 0xa9, 0xbc,
+	0x7a, 0xeb, 0x54, 0x5e, 0xbf, 0x75, 0x2a, 0x0f, 0x3f, 0x1b, 0x0a, 0x39, 0x9a, 0x1e, 0xac, 0x0f,
+	0xe2, 0xb1, 0x7a, 0xab, 0x5c, 0xea, 0x04, 0xfa, 0x93, 0x06, 0x8f, 0x2f, 0x0d, 0xe3, 0xcb, 0xf9,
+	0xcb, 0xe6, 0x45, 0xb5, 0xbe, 0xb9, 0xd7, 0x5b, 0xf7, 0x65, 0x70, 0x60, 0xe8, 0xc7, 0x4c, 0xe7,
+	0xdf, 0x01, 0x00, 0xc4, 0xce, 0x36, 0x01, 0xf5, 0x08, 0x00, 0x00,
 }
 
 func (x CordType) String() string {
@@ -14,7 +14,48 @@ func (root *CellNode[AppT]) Root() *CellNode[AppT] {
 	return root
 }
 
+// NotifyPins calls Pin.Notify() for this cell on each StateSync_Maintain pin containing it.
+func (root *CellNode[AppT]) NotifyPins(attrIDs ...tag.ID) {
+	root.notifyPins(nil, attrIDs)
+}
+
+func (root *CellNode[AppT]) notifyPins(except *Pin[AppT], attrIDs []tag.ID) {
+	root.mu.Lock()
+	pins := make([]*Pin[AppT], 0, len(root.pins))
+	for pin := range root.pins {
+		if pin != except {
+			pins = append(pins, pin)
+		}
+	}
+	root.mu.Unlock()
+
+	for _, pin := range pins {
+		pin.Notify(root.ID, attrIDs...)
+	}
+}
+
+func (root *CellNode[AppT]) addPin(pin *Pin[AppT]) {
+	root.mu.Lock()
+	if root.pins == nil {
+		root.pins = make(map[*Pin[AppT]]struct{})
+	}
+	root.pins[pin] = struct{}{}
+	root.mu.Unlock()
+}
+
+func (root *CellNode[AppT]) removePin(pin *Pin[AppT]) {
+	root.mu.Lock()
+	delete(root.pins, pin)
+	root.mu.Unlock()
+}
+
 func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Requester) (amp.Pin, error) {
+	return pinAndServe(cell, app, nil, op)
+}
+
+// pinAndServe pins the given cell and serves the given request, where the pin is started as a child of the given
+// parent pin (or of the app if parent is nil).
+func pinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, parent *Pin[AppT], op amp.Requester) (amp.Pin, error) {
 	root := cell.Root()
 	if root.ID.IsNil() {
 		root.ID = tag.Now()
@@ -24,7 +65,15 @@ func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Request
 		Op:       op,
 		App:      app,
 		Cell:     cell,
-		children: make(map[tag.ID]Cell[AppT]),
+		Parent:   parent,
+		pinAttrs: op.Request().AttrsToPin(),
+		linked:   make(map[tag.ID]float32),
+		notify:   make(chan struct{}, 1),
+	}
+	if window, err := WindowOf(op.Request()); err != nil {
+		return nil, err
+	} else if window != nil {
+		pin.window = *window
 	}
 
 	label := "pin: " + root.ID.Base32Suffix()
@@ -32,8 +81,14 @@ func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Request
 		label += fmt.Sprintf(", Cell.(*%v)", reflect.TypeOf(cell).Elem().Name())
 	}
 
+	var parentCtx task.Context = app
+	if parent != nil {
+		parentCtx = parent.ctx
+		parent.addSubPin(pin)
+	}
+
 	var err error
-	pin.ctx, err = app.StartChild(&task.Task{
+	pin.ctx, err = parentCtx.StartChild(&task.Task{
 		Info: task.Info{
 			Label:     label,
 			IdleClose: time.Microsecond,
@@ -51,15 +106,21 @@ func PinAndServe[AppT amp.AppInstance](cell Cell[AppT], app AppT, op amp.Request
 					pinContext.Log().Warnf("op failed: %v", err)
 				}
 			} else if op.Request().StateSync == amp.StateSync_Maintain {
-				<-pinContext.Closing()
+				err = pin.maintain(pinContext)
 			}
 			op.OnComplete(err)
 		},
 		OnClosing: func() {
 			pin.ReleasePin()
+			if parent != nil {
+				parent.removeSubPin(pin)
+			}
 		},
 	})
 	if err != nil {
+		if parent != nil {
+			parent.removeSubPin(pin)
+		}
 		return nil, err
 	}
 
@@ -83,37 +144,105 @@ func (pin *Pin[AppT]) ReleasePin() {
 	// override for cleanup
 }
 
-func (pin *Pin[AppT]) AddChild(sub Cell[AppT]) {
-	child := sub.Root()
-	childID := child.ID
-	if childID.IsNil() {
-		childID = tag.Now()
-		child.ID = childID
+func (pin *Pin[AppT]) Context() task.Context {
+	return pin.ctx
+}
+
+func (pin *Pin[AppT]) ServeRequest(op amp.Requester) (amp.Pin, error) {
+	req := op.Request()
+	if req.CommitTx != nil {
+		if err := pin.commitTx(req.CommitTx); err != nil {
+			return nil, err
+		}
+		op.OnComplete(nil)
+		return nil, nil
 	}
-	pin.children[childID] = sub
+
+	path := make([]tag.ID, 0, 1+len(req.PinTarget.GetTags()))
+	for _, ancestor := range req.PinTarget.GetTags() {
+		path = append(path, ancestor.TagID())
+	}
+	path = append(path, req.TargetID())
+
+	// A request targeting the pinned cell moves the window of this pin if it has window values, otherwise it is a no-op
+	// rather than a sub-pin of the pinned cell.
+	if len(path) == 1 && path[0] == pin.Cell.Root().ID {
+		if err := pin.moveWindow(req); err != nil {
+			return nil, err
+		}
+		op.OnComplete(nil)
+		return nil, nil
+	}
+
+	cell, parent, err := pin.Resolve(path...)
+	if err != nil {
+		return nil, err
+	}
+	return pinAndServe(cell, pin.App, parent, op)
 }
 
-func (pin *Pin[AppT]) GetCell(target tag.ID) Cell[AppT] {
-	if target == pin.Cell.Root().ID {
-		return pin.Cell
+// Resolve returns the cell at the given path of cell IDs, where path[0] is a child of the pinned cell and each
+// subsequent ID is a child of the previous, along with the open pin nearest to the cell's parent.
+//
+// Each cell along the path is found among the children of an open sub-pin of its parent or, if its parent is not
+// pinned, via ParentCell.Child().  A client sends a path as PinRequest.PinTarget.Tags followed by the target ID.
+func (pin *Pin[AppT]) Resolve(path ...tag.ID) (Cell[AppT], *Pin[AppT], error) {
+	var cell Cell[AppT] = pin.Cell
+	nearest := pin
+	parentPin := pin // pin of cell, or nil if cell is not pinned
+	for i, childID := range path {
+		if i > 0 {
+			if parentPin != nil {
+				parentPin = parentPin.subPinOf(cell.Root().ID)
+			}
+			if parentPin != nil {
+				nearest = parentPin
+			}
+		}
+
+		var child Cell[AppT]
+		if parentPin != nil {
+			child = parentPin.GetCell(childID)
+		}
+		if child == nil {
+			if parent, ok := cell.(ParentCell[AppT]); ok {
+				child = parent.Child(childID)
+			}
+		}
+		if child == nil {
+			return nil, nil, amp.ErrCellNotFound
+		}
+		cell = child
 	}
-	if cell, exists := pin.children[target]; exists {
-		return cell
+	return cell, nearest, nil
+}
+
+// subPinOf returns an open sub-pin of this pin pinning the given cell, or nil if none.
+func (pin *Pin[AppT]) subPinOf(cellID tag.ID) *Pin[AppT] {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	for sub := range pin.subPins {
+		if sub.Cell.Root().ID == cellID {
+			return sub
+		}
 	}
 	return nil
 }
 
-func (pin *Pin[AppT]) Context() task.Context {
-	return pin.ctx
+func (pin *Pin[AppT]) addSubPin(sub *Pin[AppT]) {
+	pin.mu.Lock()
+	if pin.subPins == nil {
+		pin.subPins = make(map[*Pin[AppT]]struct{})
+	}
+	pin.subPins[sub] = struct{}{}
+	pin.mu.Unlock()
 }
 
-func (pin *Pin[AppT]) ServeRequest(op amp.Requester) (amp.Pin, error) {
-	req := op.Request()
-	cell := pin.GetCell(req.TargetID())
-	if cell == nil {
-		return nil, amp.ErrCellNotFound
-	}
-	return PinAndServe(cell, pin.App, op)
+func (pin *Pin[AppT]) removeSubPin(sub *Pin[AppT]) {
+	pin.mu.Lock()
+	delete(pin.subPins, sub)
+	pin.mu.Unlock()
 }
 
 func (pin *Pin[AppT]) pushState() error {
@@ -123,76 +252,287 @@ func (pin *Pin[AppT]) pushState() error {
 		pinnedID := pin.Cell.Root().ID
 
 		w := cellWriter{
-			tx:     tx,
-			cellID: pinnedID,
+			tx:       tx,
+			cellID:   pinnedID,
+			pinAttrs: pin.pinAttrs,
 		}
 
 		tx.Upsert(amp.MetaNodeID, CellChildren.ID, pinnedID, nil) // export the root cell ID
 		pin.Cell.MarshalAttrs(&w)
 		if w.err != nil {
+			tx.ReleaseRef()
 			return w.err
 		}
 
-		for childID, child := range pin.children {
-			w.cellID = childID
-			tx.Upsert(pinnedID, CellChildren.ID, childID, nil) // link child to pinned cell
-			child.MarshalAttrs(&w)
-			if w.err != nil {
-				return w.err
+		var err error
+		if tx, err = pin.linkChildren(tx, &w, pin.windowChildren()); err != nil {
+			return err
+		}
+	}
+
+	tx.Status = amp.OpStatus_Synced
+	return pin.Op.PushTx(tx)
+}
+
+// Notify marks the given attrs of a pinned cell (the pinned cell or one of its children) as changed.
+// If no attrIDs are given, all the cell's attrs are marked.
+//
+// For a StateSync_Maintain pin, changed attrs are re-marshalled via Cell.MarshalAttrs() and pushed as an incremental
+// tx, at most once per NotifyInterval.  An attrID matches ops having that AttrID or, for cell properties (see
+// CellWriter.PutText), that SI.  Each update has OpStatus_Syncing if more changes are pending, otherwise
+// OpStatus_Synced.  Notify is safe to call from any goroutine and never blocks.
+func (pin *Pin[AppT]) Notify(cellID tag.ID, attrIDs ...tag.ID) {
+	pin.mu.Lock()
+	if pin.dirty == nil {
+		pin.dirty = make(map[tag.ID]map[tag.ID]struct{})
+	}
+	attrs, exists := pin.dirty[cellID]
+	if !exists {
+		pin.dirtyIDs = append(pin.dirtyIDs, cellID)
+	}
+	switch {
+	case len(attrIDs) == 0:
+		pin.dirty[cellID] = nil
+	case exists && attrs == nil:
+		// all attrs are already marked
+	default:
+		if attrs == nil {
+			attrs = make(map[tag.ID]struct{}, len(attrIDs))
+			pin.dirty[cellID] = attrs
+		}
+		for _, attrID := range attrIDs {
+			attrs[attrID] = struct{}{}
+		}
+	}
+	pin.mu.Unlock()
+
+	select {
+	case pin.notify <- struct{}{}:
+	default:
+	}
+}
+
+// commitTx applies a tx committed by a client to the cells of this pin (see CellCommitter).
+// Each op is unmarshalled and the edits of each target cell are validated before any edits are applied.
+func (pin *Pin[AppT]) commitTx(tx *amp.TxMsg) error {
+	type cellCommit struct {
+		cell    Cell[AppT]
+		edits   []CellEdit
+		attrIDs []tag.ID
+	}
+	var commits []*cellCommit
+	byCellID := make(map[tag.ID]*cellCommit)
+
+	reg := amp.AppContext(pin.App).Session()
+	for i := range tx.Ops {
+		op := &tx.Ops[i]
+		commit := byCellID[op.CellID]
+		if commit == nil {
+			cell := pin.GetCell(op.CellID)
+			if cell == nil {
+				return amp.ErrCode_CellNotFound.Errorf("commit: cell %s not found", op.CellID.Base32Suffix())
+			}
+			if _, ok := cell.(CellCommitter); !ok {
+				return amp.ErrCode_ViolatesAppendOnly.Errorf("commit: cell %s is read only", op.CellID.Base32Suffix())
+			}
+			commit = &cellCommit{
+				cell: cell,
+			}
+			byCellID[op.CellID] = commit
+			commits = append(commits, commit)
+		}
+
+		edit := CellEdit{
+			Op: op,
+		}
+		if op.OpCode == amp.TxOpCode_UpsertElement {
+			val, err := reg.MakeValue(op.AttrID)
+			if err != nil {
+				return amp.ErrCode_CommitFailed.Wrap(err)
+			}
+			if err = tx.UnmarshalOpValue(i, val); err != nil {
+				return amp.ErrCode_CommitFailed.Wrap(err)
+			}
+			edit.Value = val
+		}
+		commit.edits = append(commit.edits, edit)
+
+		// Cell properties are marked by SI (see Pin.Notify)
+		attrID := op.AttrID
+		if attrID == CellProperties.ID {
+			attrID = op.SI
+		}
+		commit.attrIDs = append(commit.attrIDs, attrID)
+	}
+
+	for _, commit := range commits {
+		if err := commit.cell.(CellCommitter).ValidateEdits(commit.edits); err != nil {
+			return commitErr(err)
+		}
+	}
+	for _, commit := range commits {
+		if err := commit.cell.(CellCommitter).CommitEdits(commit.edits); err != nil {
+			return commitErr(err)
+		}
+		commit.cell.Root().notifyPins(pin, commit.attrIDs)
+	}
+	return nil
+}
+
+func commitErr(err error) error {
+	if amp.GetErrCode(err) == amp.ErrCode_UnnamedErr {
+		err = amp.ErrCode_CommitFailed.Wrap(err)
+	}
+	return err
+}
+
+// maintain pushes changes marked via Notify() until the pin is closed.
+func (pin *Pin[AppT]) maintain(ctx task.Context) error {
+	interval := pin.NotifyInterval
+	if interval <= 0 {
+		interval = DefaultNotifyInterval
+	}
+
+	pin.setMaintaining(true)
+	defer pin.setMaintaining(false)
+
+	lastPush := time.Now()
+	for {
+		select {
+		case <-pin.notify:
+		case <-ctx.Closing():
+			return nil
+		}
+
+		// Coalesce notifications arriving within interval of the last push
+		if wait := time.Until(lastPush.Add(interval)); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Closing():
+				return nil
 			}
 		}
+		lastPush = time.Now()
+
+		if err := pin.pushLinks(); err != nil {
+			return err
+		}
+		if err := pin.pushChanges(); err != nil {
+			return err
+		}
+	}
+}
+
+// pushChanges pushes the attrs marked via Notify() as an incremental tx, where cells are marshalled in the order
+// they were marked so that the tx is deterministic.
+func (pin *Pin[AppT]) pushChanges() error {
+	pin.mu.Lock()
+	dirty, dirtyIDs := pin.dirty, pin.dirtyIDs
+	pin.dirty, pin.dirtyIDs = nil, nil
+	pin.mu.Unlock()
+
+	if len(dirty) == 0 {
+		return nil
 	}
 
+	tx := amp.NewTxMsg(true)
+	w := cellWriter{
+		tx:       tx,
+		pinAttrs: pin.pinAttrs,
+	}
+	pinnedID := pin.Cell.Root().ID
+	for _, cellID := range dirtyIDs {
+		attrs := dirty[cellID]
+		cell := pin.GetCell(cellID)
+		if cell == nil {
+			continue
+		}
+		if _, linked := pin.linked[cellID]; !linked && cellID != pinnedID {
+			continue // not in the pin's window
+		}
+		w.cellID = cellID
+		w.filter = attrs
+		cell.MarshalAttrs(&w)
+		if w.err != nil {
+			tx.ReleaseRef()
+			return w.err
+		}
+	}
+
+	if len(tx.Ops) == 0 {
+		tx.ReleaseRef()
+		return nil
+	}
+
+	// If changes were marked in the meantime, they are pushed next
+	pin.mu.Lock()
+	pending := len(pin.dirty) > 0
+	pin.mu.Unlock()
+
 	tx.Status = amp.OpStatus_Synced
+	if pending {
+		tx.Status = amp.OpStatus_Syncing
+	}
 	return pin.Op.PushTx(tx)
 }
 
 type cellWriter struct {
-	cellID tag.ID     // cache for Cell.Root().ID
-	tx     *amp.TxMsg // in-progress transaction
-	err    error
+	cellID   tag.ID              // cache for Cell.Root().ID
+	tx       *amp.TxMsg          // in-progress transaction
+	pinAttrs *amp.AttrSelector   // if set, only ops selected by the client's PinRequest.PinAttrs are written
+	filter   map[tag.ID]struct{} // if set, only ops having one of these AttrIDs or SIs are written
+	err      error
 }
 
-func (w *cellWriter) PutText(propertyID tag.ID, val string) {
+// put marshals the given op unless it is excluded by w.pinAttrs or w.filter.
+func (w *cellWriter) put(op *amp.TxOp, val tag.Value) {
 	if w.err != nil {
 		return
 	}
+	if !w.pinAttrs.Selects(op.AttrID, op.SI) {
+		return
+	}
+	if w.filter != nil {
+		_, hasAttr := w.filter[op.AttrID]
+		_, hasSI := w.filter[op.SI]
+		if !hasAttr && !hasSI {
+			return
+		}
+	}
+	if err := w.tx.MarshalOp(op, val); err != nil {
+		w.err = err
+	}
+}
+
+func (w *cellWriter) PutText(propertyID tag.ID, val string) {
 	txOp := amp.TxOp{
 		OpCode: amp.TxOpCode_UpsertElement,
 		CellID: w.cellID,
 		AttrID: CellProperties.ID,
 		SI:     propertyID,
 	}
-	err := w.tx.MarshalOp(&txOp, &amp.Tag{
+	w.put(&txOp, &amp.Tag{
 		Text: val,
 	})
-	if err != nil {
-		w.err = err
-	}
 }
 
 func (w *cellWriter) PutItem(propertyID tag.ID, val tag.Value) {
-	if w.err != nil {
-		return
-	}
 	txOp := amp.TxOp{
 		OpCode: amp.TxOpCode_UpsertElement,
 		CellID: w.cellID,
 		AttrID: CellProperties.ID,
 		SI:     propertyID,
 	}
-	if err := w.tx.MarshalOp(&txOp, val); err != nil {
-		w.err = err
-	}
+	w.put(&txOp, val)
 }
 
 func (w *cellWriter) Upsert(op *amp.TxOp, val tag.Value) {
-	if w.err != nil {
-		return
-	}
-	if err := w.tx.MarshalOp(op, val); err != nil {
-		w.err = err
+	if op.CellID.IsNil() {
+		txOp := *op
+		txOp.CellID = w.cellID
+		op = &txOp
 	}
+	w.put(op, val)
 }
 
 /*
@@ -0,0 +1,192 @@
+package std
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// WindowOf returns the Window given by the URL values of a request, or nil if no window was requested.
+func WindowOf(req *amp.Request) (*Window, error) {
+	window := &Window{}
+	given, err := window.readValues(req.Values)
+	if err != nil || !given {
+		return nil, err
+	}
+	return window, nil
+}
+
+// readValues sets the offset and/or limit of this window given in the URL values, reporting if either was given.
+func (window *Window) readValues(values url.Values) (bool, error) {
+	offset, hasOffset := values[WindowOffset]
+	limit, hasLimit := values[WindowLimit]
+
+	var err error
+	if hasOffset {
+		if window.Offset, err = strconv.Atoi(offset[0]); err != nil || window.Offset < 0 {
+			return false, amp.ErrCode_BadRequest.Errorf("bad window offset %q", offset[0])
+		}
+	}
+	if hasLimit {
+		if window.Limit, err = strconv.Atoi(limit[0]); err != nil || window.Limit < 0 {
+			return false, amp.ErrCode_BadRequest.Errorf("bad window limit %q", limit[0])
+		}
+	}
+	return hasOffset || hasLimit, nil
+}
+
+// moveWindow moves the window of this pin to the window values of the given request, pushed on its next update.
+// The offset or limit of the window is kept if not given, so a request having no window values leaves it unchanged.
+func (pin *Pin[AppT]) moveWindow(req *amp.Request) error {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	window := pin.window
+	given, err := window.readValues(req.Values)
+	if err != nil || !given {
+		return err
+	}
+	if pin.Op.Request().StateSync != amp.StateSync_Maintain {
+		return amp.ErrCode_BadRequest.Error("window can only be moved for a maintained pin")
+	}
+
+	pin.window = window
+	pin.relinkLocked()
+	return nil
+}
+
+// windowChildren returns the children within the window of this pin in order and clears pin.relink since they are
+// to be linked.
+func (pin *Pin[AppT]) windowChildren() []childEntry[AppT] {
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+
+	pin.relink = false
+	entries := pin.children.entries
+	start := min(pin.window.Offset, len(entries))
+	end := len(entries)
+	if pin.window.Limit > 0 {
+		end = min(start+pin.window.Limit, end)
+	}
+
+	children := make([]childEntry[AppT], end-start)
+	for i, entry := range entries[start:end] {
+		children[i] = *entry
+	}
+	return children
+}
+
+// linkChildren links the given children to the pinned cell and marshals their attrs, pushing tx as an interim update
+// each time it holds ChildrenPerTx children.  Returns the tx holding the remaining children, which is to be pushed next.
+func (pin *Pin[AppT]) linkChildren(tx *amp.TxMsg, w *cellWriter, children []childEntry[AppT]) (*amp.TxMsg, error) {
+	perTx := pin.ChildrenPerTx
+	if perTx <= 0 {
+		perTx = DefaultChildrenPerTx
+	}
+
+	n := 0
+	for _, child := range children {
+		if n == perTx {
+			tx.Status = amp.OpStatus_Syncing
+			if err := pin.Op.PushTx(tx); err != nil {
+				return nil, err
+			}
+			tx = amp.NewTxMsg(true)
+			w.tx = tx
+			n = 0
+		}
+
+		pin.link(tx, &child)
+		w.cellID = child.id
+		child.cell.MarshalAttrs(w)
+		if w.err != nil {
+			tx.ReleaseRef()
+			return nil, w.err
+		}
+		n++
+	}
+	return tx, nil
+}
+
+// link links the given child to the pinned cell, where the link's Tag.Ordering is the rank of the child.
+func (pin *Pin[AppT]) link(tx *amp.TxMsg, child *childEntry[AppT]) {
+	tx.Upsert(pin.Cell.Root().ID, CellChildren.ID, child.id, &amp.Tag{
+		Ordering: child.rank,
+	})
+	pin.linked[child.id] = child.rank
+}
+
+// pushLinks pushes changes to the links of this pin's children after children were added, moved, or removed or
+// the window was moved.  Children entering the window are pushed in full and children leaving it are unlinked.
+func (pin *Pin[AppT]) pushLinks() error {
+	pin.mu.Lock()
+	relink := pin.relink
+	pin.mu.Unlock()
+
+	if !relink {
+		return nil
+	}
+
+	children := pin.windowChildren()
+	inWindow := make(map[tag.ID]struct{}, len(children))
+	for _, child := range children {
+		inWindow[child.id] = struct{}{}
+	}
+
+	var leaving []tag.ID
+	for childID := range pin.linked {
+		if _, keep := inWindow[childID]; !keep {
+			leaving = append(leaving, childID)
+		}
+	}
+	sort.Slice(leaving, func(i, j int) bool {
+		return leaving[i].CompareTo(leaving[j]) < 0
+	})
+
+	tx := amp.NewTxMsg(true)
+	pinnedID := pin.Cell.Root().ID
+	for _, childID := range leaving {
+		op := amp.TxOp{
+			OpCode: amp.TxOpCode_DeleteElement,
+			CellID: pinnedID,
+			AttrID: CellChildren.ID,
+			SI:     childID,
+			EditID: tx.NextEditID(tag.ID{}),
+		}
+		if err := tx.MarshalOp(&op, nil); err != nil {
+			tx.ReleaseRef()
+			return err
+		}
+		delete(pin.linked, childID)
+	}
+
+	// Children already linked are only relinked if moved
+	var entering []childEntry[AppT]
+	for i := range children {
+		child := &children[i]
+		rank, linked := pin.linked[child.id]
+		if !linked {
+			entering = append(entering, *child)
+		} else if rank != child.rank {
+			pin.link(tx, child)
+		}
+	}
+
+	w := cellWriter{
+		tx:       tx,
+		pinAttrs: pin.pinAttrs,
+	}
+	tx, err := pin.linkChildren(tx, &w, entering)
+	if err != nil {
+		return err
+	}
+	if len(tx.Ops) == 0 {
+		tx.ReleaseRef()
+		return nil
+	}
+	tx.Status = amp.OpStatus_Synced
+	return pin.Op.PushTx(tx)
+}
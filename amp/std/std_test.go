@@ -0,0 +1,82 @@
+package std_test
+
+import (
+	"testing"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// txWriter implements std.CellWriter by writing to a tx.
+type txWriter struct {
+	tx     *amp.TxMsg
+	cellID tag.ID
+}
+
+func (w *txWriter) Upsert(op *amp.TxOp, val tag.Value) {
+	w.tx.Upsert(w.cellID, op.AttrID, op.SI, val)
+}
+
+func (w *txWriter) PutText(propertyID tag.ID, val string) {
+	w.PutItem(propertyID, &amp.Tag{Text: val})
+}
+
+func (w *txWriter) PutItem(propertyID tag.ID, val tag.Value) {
+	w.tx.Upsert(w.cellID, std.CellProperties.ID, propertyID, val)
+}
+
+type track struct {
+	Title    string       `amp:"text.Tag.label"`
+	Subtitle string       `amp:"text.Tag.caption,omitempty"`
+	Glyph    *amp.Tag     `amp:"Tag.glyphs"`
+	Position std.Position `amp:"Position,attr"`
+	Notes    string       // not bound
+}
+
+func TestCellBinding(t *testing.T) {
+	src := &track{
+		Title: "Blue in Green",
+		Glyph: &amp.Tag{
+			URL: std.GenericGlyphURL + "audio/mpeg",
+		},
+		Position: std.Position{
+			Q: 1.5,
+		},
+		Notes: "unbound",
+	}
+
+	tx := amp.NewTxMsg(true)
+	cellID := tag.Now()
+	if err := std.MarshalStruct(&txWriter{tx, cellID}, src); err != nil {
+		t.Fatalf("MarshalStruct failed: %v", err)
+	}
+	if len(tx.Ops) != 3 {
+		t.Fatalf("expected 3 ops, got %d", len(tx.Ops))
+	}
+	label := amp.Tag{}
+	if err := tx.Load(cellID, std.CellProperties.ID, std.CellLabel, &label); err != nil || label.Text != src.Title {
+		t.Fatalf("expected title to be bound to CellLabel, got %q (%v)", label.Text, err)
+	}
+
+	dst := &track{
+		Subtitle: "unchanged",
+	}
+	if err := std.UnmarshalStruct(tx, cellID, dst); err != nil {
+		t.Fatalf("UnmarshalStruct failed: %v", err)
+	}
+	if dst.Title != src.Title || dst.Subtitle != "unchanged" || dst.Notes != "" {
+		t.Fatalf("unexpected text fields: %+v", dst)
+	}
+	if dst.Glyph == nil || dst.Glyph.URL != src.Glyph.URL || dst.Position.Q != 1.5 {
+		t.Fatalf("unexpected value fields: %+v", dst)
+	}
+
+	// Unsupported fields are reported
+	type badCell struct {
+		Count int `amp:"count"`
+	}
+	if _, err := std.BindCell(&badCell{}); amp.GetErrCode(err) != amp.ErrCode_BadSchema {
+		t.Fatalf("expected ErrCode_BadSchema, got %v", err)
+	}
+}
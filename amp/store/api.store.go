@@ -0,0 +1,60 @@
+// Package store persists cell state within a single directory, typically under amp.AppContext.LocalDataPath().
+//
+// A store is a small log-structured merge (LSM) layout:
+//
+//   - "wal.log" is a write-ahead log: each committed TxMsg is appended as a checksummed record and synced before
+//     Commit() returns, so a commit is either fully replayed after a crash or not at all.
+//   - "seg-<seq>.sst" is an immutable segment: the cell elements (TxOps) at the time of a compaction, sorted by
+//     the bytes of key CellID|AttrID|SI|EditID, where each tag.ID is written via tag.ID.Put24().  Since Put24()
+//     zigzag encodes tag.ID[0], this is not tag.ID.CompareTo() order when tag.ID[0] differs in sign, but it is
+//     stable, so a given state always yields the same segment.
+//
+// When the write-ahead log grows past Opts.MaxLogSize, the current state is written to a new segment, which then
+// replaces the log and prior segments.  Deletion tombstones are not retained by a compaction.
+//
+// Opening a store replays its segments and log into an amp.CellStore, which then reflects each Commit().
+package store
+
+import (
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+// Opts specifies how a Store is opened.
+type Opts struct {
+	Dir        string // directory holding the store's files -- created if it does not exist
+	MaxLogSize int64  // write-ahead log size at which Commit() compacts -- if <= 0, compaction is manual
+	NoSync     bool   // if set, commits are not synced to disk (faster, but a crash can lose recent commits)
+}
+
+// DefaultOpts returns the default Opts for a store in the given directory.
+func DefaultOpts(dir string) Opts {
+	return Opts{
+		Dir:        dir,
+		MaxLogSize: 8 << 20,
+	}
+}
+
+// Store persists cell state, replaying it into an amp.CellStore when opened -- concurrency safe.
+type Store interface {
+
+	// Returns the state of this store, replayed from disk when opened and updated by Commit().
+	// Changes made to the returned CellStore directly are not persisted.
+	Cells() amp.CellStore
+
+	// Durably appends tx to the write-ahead log and applies it to Cells().
+	// tx is validated first (see amp.TxMsg.Validate) and is not retained.
+	// Once tx is appended, Commit() succeeds -- a failed compaction is logged and retried on a later Commit().
+	Commit(tx *amp.TxMsg) error
+
+	// Writes the current state to a new segment, replacing the write-ahead log and prior segments.
+	Compact() error
+
+	// Closes this store -- subsequent calls to Commit() fail.
+	Close() error
+}
+
+// Open opens (or creates) the store in opts.Dir and replays it.
+// A partially written record at the end of the write-ahead log (e.g. due to a crash) is discarded.
+func Open(opts Opts) (Store, error) {
+	return openStore(opts)
+}
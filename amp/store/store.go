@@ -0,0 +1,204 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/log"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+const (
+	logName   = "wal.log"
+	segPrefix = "seg-"
+	segSuffix = ".sst"
+	tmpSuffix = ".tmp"
+	filePerms = os.FileMode(0600)
+)
+
+// store implements Store
+type store struct {
+	opts    Opts
+	logger  log.Logger
+	mu      sync.Mutex
+	cells   amp.CellStore
+	log     *os.File // nil once closed
+	logSize int64    // size of the log up to the end of the last complete record
+	segSeq  uint64   // sequence number of the current segment (or 0 if none)
+	scrap   []byte   // used to marshal txs
+	record  []byte   // used to assemble log records
+}
+
+func openStore(opts Opts) (*store, error) {
+	if err := utils.EnsureDirAndMaxPerms(opts.Dir, utils.DefaultDirPerms); err != nil {
+		return nil, err
+	}
+
+	st := &store{
+		opts:   opts,
+		logger: log.NewLogger("store"),
+		cells:  amp.NewCellStore(),
+	}
+
+	// The latest segment contains the complete state as of its compaction, so prior segments are obsolete.
+	segs, err := st.listSegments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segs) > 0 {
+		st.segSeq = segs[len(segs)-1]
+		if err = readSegment(st.segPath(st.segSeq), st.cells); err != nil {
+			return nil, err
+		}
+		st.removeSegments(segs[:len(segs)-1])
+	}
+
+	st.log, err = os.OpenFile(filepath.Join(opts.Dir, logName), os.O_RDWR|os.O_CREATE, filePerms)
+	if err != nil {
+		return nil, err
+	}
+	if err = st.replayLog(); err != nil {
+		st.log.Close()
+		return nil, err
+	}
+	return st, nil
+}
+
+func (st *store) Cells() amp.CellStore {
+	return st.cells
+}
+
+func (st *store) Commit(tx *amp.TxMsg) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.log == nil {
+		return amp.ErrShuttingDown
+	}
+	if err := st.appendLog(tx); err != nil {
+		return amp.ErrCode_CommitFailed.Wrap(err)
+	}
+
+	// Apply() only fails if tx is invalid, so once tx is durably logged (and would be replayed), it is also applied.
+	st.cells.Apply(tx)
+
+	// The commit is durable, so a failed compaction only defers it to a later commit.
+	if st.opts.MaxLogSize > 0 && st.logSize >= st.opts.MaxLogSize {
+		if err := st.compact(); err != nil {
+			st.logger.Warnf("compaction failed: %v", err)
+		}
+	}
+	return nil
+}
+
+func (st *store) Compact() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.log == nil {
+		return amp.ErrShuttingDown
+	}
+	return st.compact()
+}
+
+// compact writes the current state to a new segment and then truncates the log.
+// A crash at any point leaves a store that replays to the same state.
+func (st *store) compact() error {
+	snap := amp.NewTxMsg(false)
+	defer snap.ReleaseRef()
+	st.cells.Snapshot(snap)
+
+	seq := st.segSeq + 1
+	if err := writeSegment(st.segPath(seq), snap); err != nil {
+		return err
+	}
+	if err := syncDir(st.opts.Dir); err != nil {
+		return err
+	}
+
+	// Once the new segment is in place, the log and prior segments are redundant.
+	if err := st.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := st.log.Seek(0, 0); err != nil {
+		return err
+	}
+	st.logSize = 0
+	if err := st.log.Sync(); err != nil {
+		return err
+	}
+
+	if st.segSeq > 0 {
+		st.removeSegments([]uint64{st.segSeq})
+	}
+	st.segSeq = seq
+	return nil
+}
+
+func (st *store) Close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.log == nil {
+		return nil
+	}
+	err := st.log.Close()
+	st.log = nil
+	return err
+}
+
+func (st *store) segPath(seq uint64) string {
+	return filepath.Join(st.opts.Dir, segPrefix+strconv.FormatUint(seq, 10)+segSuffix)
+}
+
+// listSegments returns the sequence numbers of the segments in the store's dir in ascending order.
+// Temp files left by an interrupted compaction are removed.
+func (st *store) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(st.opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, segPrefix) {
+			continue
+		}
+		if strings.HasSuffix(name, tmpSuffix) {
+			os.Remove(filepath.Join(st.opts.Dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segPrefix), segSuffix), 10, 64)
+		if err == nil && strings.HasSuffix(name, segSuffix) {
+			segs = append(segs, seq)
+		}
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i] < segs[j] })
+	return segs, nil
+}
+
+func (st *store) removeSegments(segs []uint64) {
+	for _, seq := range segs {
+		os.Remove(st.segPath(seq))
+	}
+}
+
+// syncDir syncs a directory so that renames within it are durable.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
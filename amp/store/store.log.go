@@ -0,0 +1,94 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+// Each log record is a serialized TxMsg preceded by:
+//
+//	0:4 -- payload length (little endian)
+//	4:8 -- CRC-32C of the payload (little endian)
+const logRecordHeaderSize = 8
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// appendLog appends tx to the log as a single record and syncs it.
+// On failure, the log is truncated to its prior size so that a partial record does not precede later records.
+func (st *store) appendLog(tx *amp.TxMsg) error {
+	tx.MarshalToBuffer(&st.scrap)
+	if len(st.scrap) > math.MaxUint32 {
+		return amp.ErrCode_CommitFailed.Errorf("tx of %d bytes exceeds the log record limit", len(st.scrap))
+	}
+
+	var header [logRecordHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(st.scrap)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.Checksum(st.scrap, crcTable))
+	st.record = append(append(st.record[:0], header[:]...), st.scrap...)
+
+	_, err := st.log.Write(st.record)
+	if err == nil && !st.opts.NoSync {
+		err = st.log.Sync()
+	}
+	if err != nil {
+		st.log.Truncate(st.logSize)
+		st.log.Seek(st.logSize, io.SeekStart)
+		return err
+	}
+	st.logSize += int64(len(st.record))
+	return nil
+}
+
+// replayLog applies each complete record of the log to the store's cells.
+// The log is truncated after the last complete record, discarding a record torn by a crash.
+func (st *store) replayLog() error {
+	data, err := io.ReadAll(st.log)
+	if err != nil {
+		return err
+	}
+
+	// Commit() logs a tx of any size and each record is protected by its CRC, so replay is not size limited.
+	reader := amp.TxReader{
+		MaxBodyLen: math.MaxInt,
+		MaxDataLen: math.MaxInt,
+	}
+
+	ofs := 0
+	for len(data)-ofs >= logRecordHeaderSize {
+		payloadLen := int(binary.LittleEndian.Uint32(data[ofs:]))
+		checksum := binary.LittleEndian.Uint32(data[ofs+4:])
+		start := ofs + logRecordHeaderSize
+		if payloadLen > len(data)-start {
+			break
+		}
+		payload := data[start : start+payloadLen]
+		if crc32.Checksum(payload, crcTable) != checksum {
+			break
+		}
+
+		tx, err := reader.ReadTxMsg(bytes.NewReader(payload))
+		if err != nil {
+			return amp.ErrCode_DataFailure.Errorf("store log record at %d: %v", ofs, err)
+		}
+		err = st.cells.Apply(tx)
+		tx.ReleaseRef()
+		if err != nil {
+			return err
+		}
+		ofs = start + payloadLen
+	}
+
+	st.logSize = int64(ofs)
+	if ofs < len(data) {
+		if err = st.log.Truncate(st.logSize); err != nil {
+			return err
+		}
+	}
+	_, err = st.log.Seek(st.logSize, io.SeekStart)
+	return err
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"sort"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// A segment is laid out as:
+//
+//	segMagic
+//	records, sorted by key:  key[segKeySize] | TxOpCode (1 byte) | uvarint(data length) | data
+//	CRC-32C of all preceding bytes (4 bytes, little endian)
+const (
+	segMagic   = "ampseg01"
+	segKeySize = 4 * 24 // CellID|AttrID|SI|EditID
+)
+
+// opKey returns the key of the given op, where each tag.ID is written via tag.ID.Put24().
+func opKey(op *amp.TxOp) (key [segKeySize]byte) {
+	op.CellID.Put24(key[0:])
+	op.AttrID.Put24(key[24:])
+	op.SI.Put24(key[48:])
+	op.EditID.Put24(key[72:])
+	return key
+}
+
+// writeSegment writes the ops of tx to a new segment at the given path, which appears atomically once complete.
+func writeSegment(path string, tx *amp.TxMsg) error {
+	type entry struct {
+		key [segKeySize]byte
+		op  *amp.TxOp
+	}
+	entries := make([]entry, len(tx.Ops))
+	for i := range tx.Ops {
+		entries[i] = entry{
+			key: opKey(&tx.Ops[i]),
+			op:  &tx.Ops[i],
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key[:], entries[j].key[:]) < 0
+	})
+
+	buf := make([]byte, 0, len(segMagic)+len(entries)*(segKeySize+4)+len(tx.DataStore)+4)
+	buf = append(buf, segMagic...)
+	for _, entry := range entries {
+		op := entry.op
+		buf = append(buf, entry.key[:]...)
+		buf = append(buf, byte(op.OpCode))
+		buf = binary.AppendUvarint(buf, op.DataLen)
+		buf = append(buf, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.Checksum(buf, crcTable))
+
+	tmpPath := path + tmpSuffix
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePerms)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
+
+// readSegment applies the ops of the segment at the given path to cells.
+func readSegment(path string, cells amp.CellStore) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	segErr := func(msg string) error {
+		return amp.ErrCode_DataFailure.Errorf("store segment %q: %s", path, msg)
+	}
+	if len(buf) < len(segMagic)+4 || string(buf[:len(segMagic)]) != segMagic {
+		return segErr("bad header")
+	}
+	body := buf[:len(buf)-4]
+	if crc32.Checksum(body, crcTable) != binary.LittleEndian.Uint32(buf[len(body):]) {
+		return segErr("checksum mismatch")
+	}
+
+	tx := amp.NewTxMsg(true)
+	defer tx.ReleaseRef()
+
+	for p := len(segMagic); p < len(body); {
+		if len(body)-p < segKeySize+1 {
+			return segErr("truncated record")
+		}
+		key := body[p : p+segKeySize]
+		op := amp.TxOp{
+			CellID: tag.From24(key[0:]),
+			AttrID: tag.From24(key[24:]),
+			SI:     tag.From24(key[48:]),
+			EditID: tag.From24(key[72:]),
+			OpCode: amp.TxOpCode(body[p+segKeySize]),
+		}
+		p += segKeySize + 1
+
+		dataLen, n := binary.Uvarint(body[p:])
+		if n <= 0 || dataLen > uint64(len(body)-p-n) {
+			return segErr("bad record length")
+		}
+		p += n
+		tx.MarshalOpWithBuf(&op, body[p:p+int(dataLen)])
+		p += int(dataLen)
+	}
+	return cells.Apply(tx)
+}
@@ -0,0 +1,199 @@
+package store_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/store"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+var testAttrID = (&amp.Tag{}).TagSpec().ID
+
+func commitText(t *testing.T, st store.Store, cell, SI uint64, text string) {
+	t.Helper()
+	tx := amp.NewTxMsg(true)
+	if text == "" {
+		op := amp.TxOp{
+			OpCode: amp.TxOpCode_DeleteElement,
+			CellID: tag.ID{0, 0, cell},
+			AttrID: testAttrID,
+			SI:     tag.ID{0, 0, SI},
+			EditID: tx.NextEditID(tag.ID{}),
+		}
+		tx.MarshalOp(&op, nil)
+	} else {
+		tx.Upsert(tag.ID{0, 0, cell}, testAttrID, tag.ID{0, 0, SI}, &amp.Tag{Text: text})
+	}
+	if err := st.Commit(tx); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	tx.ReleaseRef()
+}
+
+func loadText(st store.Store, cell, SI uint64) string {
+	val := amp.Tag{}
+	if err := st.Cells().Load(tag.ID{0, 0, cell}, testAttrID, tag.ID{0, 0, SI}, &val); err != nil {
+		return ""
+	}
+	return val.Text
+}
+
+func openStore(t *testing.T, opts store.Opts) store.Store {
+	t.Helper()
+	st, err := store.Open(opts)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return st
+}
+
+func checkTexts(t *testing.T, st store.Store, want map[uint64]string) {
+	t.Helper()
+	for SI, text := range want {
+		if got := loadText(st, 1, SI); got != text {
+			t.Errorf("SI %d: expected %q, got %q", SI, text, got)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	opts := store.DefaultOpts(filepath.Join(t.TempDir(), "cells"))
+	st := openStore(t, opts)
+	commitText(t, st, 1, 1, "one")
+	commitText(t, st, 1, 2, "two")
+	commitText(t, st, 1, 3, "three")
+	commitText(t, st, 1, 2, "")
+	commitText(t, st, 1, 1, "one (edited)")
+	st.Close()
+
+	if err := st.Commit(amp.NewTxMsg(true)); err != amp.ErrShuttingDown {
+		t.Fatalf("expected ErrShuttingDown, got %v", err)
+	}
+
+	want := map[uint64]string{1: "one (edited)", 2: "", 3: "three"}
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+
+	// A record torn by a crash is discarded and later commits are still replayed
+	st.Close()
+	logPath := filepath.Join(opts.Dir, "wal.log")
+	f, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{200, 0, 0, 0, 1, 2, 3, 4, 5})
+	f.Close()
+
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+	commitText(t, st, 1, 4, "four")
+	st.Close()
+
+	want[4] = "four"
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+	st.Close()
+}
+
+func TestCompact(t *testing.T) {
+	opts := store.DefaultOpts(t.TempDir())
+	opts.MaxLogSize = 1024
+	opts.NoSync = true
+
+	st := openStore(t, opts)
+	want := map[uint64]string{}
+	for i := uint64(0); i < 100; i++ {
+		text := fmt.Sprintf("edit %d", i)
+		if i%10 == 9 {
+			text = ""
+		}
+		commitText(t, st, 1, i%20, text)
+		want[i%20] = text
+	}
+	checkTexts(t, st, want)
+	st.Close()
+
+	segs, _ := filepath.Glob(filepath.Join(opts.Dir, "seg-*"))
+	if len(segs) != 1 {
+		t.Fatalf("expected 1 segment, got %v", segs)
+	}
+	if info, err := os.Stat(filepath.Join(opts.Dir, "wal.log")); err != nil || info.Size() >= opts.MaxLogSize {
+		t.Fatalf("expected log to be compacted")
+	}
+
+	// Files left by an interrupted compaction are ignored
+	os.WriteFile(filepath.Join(opts.Dir, "seg-99.sst.tmp"), []byte("partial"), 0600)
+
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+	if err := st.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	st.Close()
+
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+	st.Close()
+
+	if segs, _ = filepath.Glob(filepath.Join(opts.Dir, "seg-*")); len(segs) != 1 {
+		t.Fatalf("expected 1 segment, got %v", segs)
+	}
+
+	// A corrupt segment is reported
+	os.WriteFile(segs[0], []byte("ampseg01 corrupt"), 0600)
+	if _, err := store.Open(opts); amp.GetErrCode(err) != amp.ErrCode_DataFailure {
+		t.Fatalf("expected ErrCode_DataFailure, got %v", err)
+	}
+}
+
+func TestCompactFailure(t *testing.T) {
+	opts := store.DefaultOpts(t.TempDir())
+	opts.MaxLogSize = 1024
+	opts.NoSync = true
+
+	// A non-empty dir in place of the next segment causes compaction to fail
+	st := openStore(t, opts)
+	blocker := filepath.Join(opts.Dir, "seg-1.sst")
+	os.MkdirAll(filepath.Join(blocker, "blocker"), 0700)
+
+	// Commits are durable regardless, so they succeed
+	want := map[uint64]string{}
+	for i := uint64(0); i < 50; i++ {
+		text := fmt.Sprintf("edit %d", i)
+		commitText(t, st, 1, i%20, text)
+		want[i%20] = text
+	}
+	checkTexts(t, st, want)
+	st.Close()
+
+	if info, err := os.Stat(filepath.Join(opts.Dir, "wal.log")); err != nil || info.Size() < opts.MaxLogSize {
+		t.Fatalf("expected log not to be compacted")
+	}
+	os.RemoveAll(blocker)
+
+	st = openStore(t, opts)
+	checkTexts(t, st, want)
+	st.Close()
+}
+
+func TestReplayLargeTx(t *testing.T) {
+	opts := store.DefaultOpts(t.TempDir())
+	opts.MaxLogSize = 0 // replay from the log rather than a segment
+	opts.NoSync = true
+
+	// A tx larger than amp.ReadTxMsg accepts is still replayed
+	st := openStore(t, opts)
+	text := strings.Repeat("x", amp.DefaultMaxTxDataLen+1)
+	commitText(t, st, 1, 1, text)
+	commitText(t, st, 1, 2, "after")
+	st.Close()
+
+	st = openStore(t, opts)
+	checkTexts(t, st, map[uint64]string{1: text, 2: "after"})
+	st.Close()
+}
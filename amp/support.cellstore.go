@@ -0,0 +1,213 @@
+package amp
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// NewCellStore returns a new, empty CellStore.
+func NewCellStore() CellStore {
+	return &cellStore{
+		cells: make(map[tag.ID]*cellState),
+	}
+}
+
+// Implements CellStore
+type cellStore struct {
+	mu    sync.RWMutex
+	cells map[tag.ID]*cellState
+}
+
+type cellState struct {
+	deletedAt tag.ID                // EditID of the latest TxOpCode_DeleteCell (or nil)
+	attrs     map[tag.ID][]cellElem // elements of each attr, sorted by SI
+}
+
+type cellElem struct {
+	SI      tag.ID
+	EditID  tag.ID
+	deleted bool   // set if this is a tombstone
+	data    []byte // serialized value
+}
+
+func (store *cellStore) Apply(tx *TxMsg) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, op := range tx.Ops {
+		cell := store.cells[op.CellID]
+		if cell == nil {
+			cell = &cellState{
+				attrs: make(map[tag.ID][]cellElem),
+			}
+			store.cells[op.CellID] = cell
+		}
+
+		switch op.OpCode {
+		case TxOpCode_UpsertElement, TxOpCode_DeleteElement:
+			elem := cellElem{
+				SI:      op.SI,
+				EditID:  op.EditID,
+				deleted: op.OpCode == TxOpCode_DeleteElement,
+			}
+			if !elem.deleted {
+				elem.data = append([]byte(nil), tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+			}
+			cell.put(op.AttrID, elem)
+		case TxOpCode_DeleteCell:
+			cell.delete(op.EditID)
+		}
+	}
+	return nil
+}
+
+// put applies the given element if it is more recent than the element (or cell deletion) it replaces.
+// Of edits having the same EditID, a deletion wins so that the result does not depend on the order they are applied.
+func (cell *cellState) put(attrID tag.ID, elem cellElem) {
+	if cell.deletedAt.IsSet() && CompareEdits(elem.EditID, cell.deletedAt) <= 0 {
+		return
+	}
+
+	elems := cell.attrs[attrID]
+	idx, found := searchElems(elems, elem.SI)
+	if found {
+		if c := CompareEdits(elem.EditID, elems[idx].EditID); c > 0 || (c == 0 && elem.deleted) {
+			elems[idx] = elem
+		}
+		return
+	}
+	elems = append(elems, cellElem{})
+	copy(elems[idx+1:], elems[idx:])
+	elems[idx] = elem
+	cell.attrs[attrID] = elems
+}
+
+// delete removes all elements of this cell not more recent than the given EditID, including those having the same EditID.
+func (cell *cellState) delete(editID tag.ID) {
+	if CompareEdits(editID, cell.deletedAt) < 0 {
+		return
+	}
+	cell.deletedAt = editID
+
+	for attrID, elems := range cell.attrs {
+		keep := elems[:0]
+		for _, elem := range elems {
+			if CompareEdits(elem.EditID, editID) > 0 {
+				keep = append(keep, elem)
+			}
+		}
+		if len(keep) == 0 {
+			delete(cell.attrs, attrID)
+		} else {
+			cell.attrs[attrID] = keep
+		}
+	}
+}
+
+// searchElems returns the index of the element having the given SI (or where it would be inserted).
+func searchElems(elems []cellElem, SI tag.ID) (int, bool) {
+	idx := sort.Search(len(elems), func(i int) bool {
+		return elems[i].SI.CompareTo(SI) >= 0
+	})
+	return idx, idx < len(elems) && elems[idx].SI == SI
+}
+
+func (store *cellStore) Load(cellID, attrID, SI tag.ID, dst tag.Value) error {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if cell := store.cells[cellID]; cell != nil {
+		elems := cell.attrs[attrID]
+		if idx, found := searchElems(elems, SI); found && !elems[idx].deleted {
+			return dst.Unmarshal(elems[idx].data)
+		}
+	}
+	return ErrPropertyNotFound
+}
+
+func (store *cellStore) Scan(cellID, attrID, minSI, maxSI tag.ID, fn func(op *TxOp, data []byte) bool) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	cell := store.cells[cellID]
+	if cell == nil {
+		return
+	}
+
+	elems := cell.attrs[attrID]
+	idx, _ := searchElems(elems, minSI)
+	for _, elem := range elems[idx:] {
+		if maxSI.IsSet() && elem.SI.CompareTo(maxSI) >= 0 {
+			break
+		}
+		if elem.deleted {
+			continue
+		}
+		op := TxOp{
+			OpCode:  TxOpCode_UpsertElement,
+			CellID:  cellID,
+			AttrID:  attrID,
+			SI:      elem.SI,
+			EditID:  elem.EditID,
+			DataLen: uint64(len(elem.data)),
+		}
+		if !fn(&op, elem.data) {
+			break
+		}
+	}
+}
+
+func (store *cellStore) Snapshot(dst *TxMsg, cellIDs ...tag.ID) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if len(cellIDs) == 0 {
+		cellIDs = make([]tag.ID, 0, len(store.cells))
+		for cellID := range store.cells {
+			cellIDs = append(cellIDs, cellID)
+		}
+		sortIDs(cellIDs)
+	}
+
+	var attrIDs []tag.ID
+	for _, cellID := range cellIDs {
+		cell := store.cells[cellID]
+		if cell == nil {
+			continue
+		}
+
+		attrIDs = attrIDs[:0]
+		for attrID := range cell.attrs {
+			attrIDs = append(attrIDs, attrID)
+		}
+		sortIDs(attrIDs)
+
+		for _, attrID := range attrIDs {
+			for _, elem := range cell.attrs[attrID] {
+				if elem.deleted {
+					continue
+				}
+				op := TxOp{
+					OpCode: TxOpCode_UpsertElement,
+					CellID: cellID,
+					AttrID: attrID,
+					SI:     elem.SI,
+					EditID: elem.EditID,
+				}
+				dst.MarshalOpWithBuf(&op, elem.data)
+			}
+		}
+	}
+}
+
+func sortIDs(IDs []tag.ID) {
+	sort.Slice(IDs, func(i, j int) bool {
+		return IDs[i].CompareTo(IDs[j]) < 0
+	})
+}
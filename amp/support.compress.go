@@ -0,0 +1,171 @@
+package amp
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// TxDataCodec specifies how a TxMsg.DataStore is encoded on the wire.
+//
+// This implementation uses the reserved TxHeader bytes (see Const_TxHeader_Size) as follows:
+//
+//	12:13 -- TxDataCodec of the DataStore following the tx body
+//	13:14 -- bitmask of the TxDataCodecs the sender can read (bit n set for codec n)
+//	14:16 -- reserved
+type TxDataCodec byte
+
+const (
+	// DataStore follows the tx body as-is.
+	TxDataCodec_Raw TxDataCodec = 0
+
+	// DataStore follows the tx body as uvarint(uncompressed length) followed by a DEFLATE stream (RFC 1951).
+	TxDataCodec_Deflate TxDataCodec = 1
+)
+
+// DataStores smaller than this are sent raw unless TxCompression.MinSize says otherwise.
+const DefaultCompressMinSize = 512
+
+// Bitmask of the TxDataCodecs ReadTxMsg() decodes, advertised in every marshalled TxHeader.
+const txDataCodecsAccepted = 1 << TxDataCodec_Deflate
+
+// DataCodec returns the TxDataCodec of the DataStore that follows the tx body.
+func (header TxHeader) DataCodec() TxDataCodec {
+	return TxDataCodec(header[12])
+}
+
+// Accepts returns true if the sender of this header can read a tx whose DataStore is encoded using the given codec.
+func (header TxHeader) Accepts(codec TxDataCodec) bool {
+	return codec == TxDataCodec_Raw || (codec < 8 && header[13]&(1<<codec) != 0)
+}
+
+// TxCompression negotiates and applies DataStore compression for one end of a connection.
+//
+// Every tx marshalled by this package advertises the codecs its sender can read.  Once a tx read via
+// TxCompression.ReadTxMsg() advertises Codec, txs marshalled via this TxCompression have their DataStore
+// compressed using Codec whenever doing so saves space.  This allows compression to be negotiated per connection
+// without a round trip, and a peer that never advertises Codec is only sent raw txs.
+//
+// ReadTxMsg() may be called concurrently with MarshalToWriter() or MarshalToBuffer(), but the latter two
+// must not be called concurrently with each other.
+type TxCompression struct {
+	Codec   TxDataCodec // codec used once accepted by the peer -- TxDataCodec_Raw disables compression
+	MinSize int         // DataStores smaller than this are sent raw -- if 0, DefaultCompressMinSize is used
+
+	peerAccepts atomic.Uint32 // TxHeader byte 13 last read from the peer
+	compressed  []byte        // scrap for compressed DataStores
+}
+
+// ReadTxMsg reads a tx via amp.ReadTxMsg() and notes which codecs the peer accepts.
+// Use a TxReader (with TxReader.Compression set) to reuse buffers or set limits.
+func (c *TxCompression) ReadTxMsg(stream io.Reader) (*TxMsg, error) {
+	r := TxReader{
+		Compression: c,
+	}
+	return r.ReadTxMsg(stream)
+}
+
+// onHeaderRead notes the codecs accepted by the peer that sent the given header.
+func (c *TxCompression) onHeaderRead(header *TxHeader) {
+	c.peerAccepts.Store(uint32(header[13]))
+}
+
+// MarshalToWriter is TxMsg.MarshalToWriter() but compresses tx.DataStore as negotiated.
+func (c *TxCompression) MarshalToWriter(tx *TxMsg, scrap *[]byte, w io.Writer) error {
+	data, codec := c.encodeDataStore(tx)
+	tx.marshalHeaderAndOps(scrap, len(data), codec)
+	if err := writeBytes(w, *scrap); err != nil {
+		return err
+	}
+	return writeBytes(w, data)
+}
+
+// MarshalToBuffer is TxMsg.MarshalToBuffer() but compresses tx.DataStore as negotiated.
+func (c *TxCompression) MarshalToBuffer(tx *TxMsg, dst *[]byte) {
+	data, codec := c.encodeDataStore(tx)
+	tx.marshalHeaderAndOps(dst, len(data), codec)
+	*dst = append(*dst, data...)
+}
+
+// encodeDataStore returns the wire encoding of tx.DataStore and the codec used.
+func (c *TxCompression) encodeDataStore(tx *TxMsg) ([]byte, TxDataCodec) {
+	minSize := c.MinSize
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+	if c.Codec != TxDataCodec_Deflate || len(tx.DataStore) < minSize || c.peerAccepts.Load()&(1<<c.Codec) == 0 {
+		return tx.DataStore, TxDataCodec_Raw
+	}
+
+	c.compressed = appendDeflate(c.compressed[:0], tx.DataStore)
+	if len(c.compressed) >= len(tx.DataStore) {
+		return tx.DataStore, TxDataCodec_Raw
+	}
+	return c.compressed, c.Codec
+}
+
+var gFlateWriters = sync.Pool{
+	New: func() interface{} {
+		w, _ := flate.NewWriter(nil, flate.BestSpeed)
+		return w
+	},
+}
+
+var gFlateReaders = sync.Pool{
+	New: func() interface{} {
+		return flate.NewReader(nil)
+	},
+}
+
+func appendDeflate(dst, src []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(src)))
+	buf := bytes.NewBuffer(dst)
+
+	w := gFlateWriters.Get().(*flate.Writer)
+	w.Reset(buf)
+	w.Write(src)
+	w.Close()
+	gFlateWriters.Put(w)
+
+	return buf.Bytes()
+}
+
+// inflate decodes a TxDataCodec_Deflate DataStore into dst, growing it as data is inflated.
+// The declared length is only trusted as a limit, so a small malformed input cannot cause a large allocation.
+func inflate(dst, src []byte, maxLen int) ([]byte, error) {
+	dataLen, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, ErrCode_MalformedTx.Error("bad compressed DataStore length")
+	}
+	if dataLen > uint64(maxLen) {
+		return nil, ErrCode_MalformedTx.Errorf("DataStore length %d exceeds limit %d", dataLen, maxLen)
+	}
+
+	r := gFlateReaders.Get().(io.ReadCloser)
+	defer gFlateReaders.Put(r)
+	r.(flate.Resetter).Reset(bytes.NewReader(src[n:]), nil)
+
+	// Read one byte past the declared length to detect data longer than declared
+	buf := bytes.NewBuffer(dst[:0])
+	if _, err := buf.ReadFrom(io.LimitReader(r, int64(dataLen)+1)); err != nil {
+		return nil, ErrCode_MalformedTx.Errorf("bad compressed DataStore: %v", err)
+	}
+	if uint64(buf.Len()) != dataLen {
+		return nil, ErrCode_MalformedTx.Errorf("compressed DataStore inflated to %d bytes, expected %d", buf.Len(), dataLen)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeBytes(w io.Writer, src []byte) error {
+	for L := 0; L < len(src); {
+		n, err := w.Write(src[L:])
+		if err != nil {
+			return err
+		}
+		L += n
+	}
+	return nil
+}
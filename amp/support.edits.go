@@ -0,0 +1,217 @@
+package amp
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// NewEditID returns the EditID of a revision made by the given tx that replaces the revision having prevEditID
+// (or that has no predecessor if prevEditID is nil), as described for TxField_EditID:
+//
+//	EditID := tag.ID{
+//	    -newTxID[0],
+//	    (prevEditID[1] ^ (newTxID[0] - prevEditID[0])) & 0xFFFFFFFF | (newTxID[1] ^ newTxID[2]) << 32,
+//	    0,
+//	}
+//
+// The low bits of EditID[1] link an edit to its predecessor and its high bits are taken from the entropy of the tx, so
+// that edits made by different replicas at the same time have distinct EditIDs.
+//
+// If txID is not more recent than prevEditID (e.g. due to clock skew between devices), newTxID[0] is advanced so that
+// an edit is always more recent than its predecessor.  See CompareEdits() and EditFollows().
+func NewEditID(txID, prevEditID tag.ID) tag.ID {
+	txTime := txID[0]
+	if prevTime := editTime(prevEditID); txTime <= prevTime {
+		txTime = prevTime + 1
+	}
+	return tag.ID{
+		-txTime,
+		editLink(txTime, prevEditID) | (txID[1]^txID[2])<<32,
+		0,
+	}
+}
+
+// NextEditID returns the EditID of a revision made by this tx that replaces the revision having prevEditID.
+func (tx *TxMsg) NextEditID(prevEditID tag.ID) tag.ID {
+	return NewEditID(tx.GenesisID(), prevEditID)
+}
+
+// EditFollows returns true if editID was derived from prevEditID via NewEditID().
+// If prevEditID is nil, true is returned if editID has no predecessor.
+func EditFollows(editID, prevEditID tag.ID) bool {
+	if int64(editID[0]) >= 0 || editID[2] != 0 {
+		return false // not a derived EditID
+	}
+	txTime := -editID[0]
+	return txTime > editTime(prevEditID) && editID[1]&0xFFFFFFFF == editLink(txTime, prevEditID)
+}
+
+// editLink returns the bits of EditID[1] that link an edit made at txTime to its predecessor.
+func editLink(txTime uint64, prevEditID tag.ID) uint64 {
+	return (prevEditID[1] ^ (txTime - prevEditID[0])) & 0xFFFFFFFF
+}
+
+// CompareEdits returns > 0 if editID is more recent than other, < 0 if it is less recent, and 0 if they are equal.
+//
+// Derived EditIDs (see NewEditID) decrease in time, whereas an EditID copied from a tx GenesisID increases in time,
+// so both are ordered by the time of the tx that made the edit.  Edits made at the same time are ordered by their
+// remaining bytes, so concurrent edits of an element resolve identically on every replica.
+func CompareEdits(editID, other tag.ID) int {
+	t1, t2 := editTime(editID), editTime(other)
+	switch {
+	case t1 < t2:
+		return -1
+	case t1 > t2:
+		return 1
+	}
+	return editID.CompareTo(other)
+}
+
+// editTime returns newTxID[0] of the tx that made the given edit.
+func editTime(editID tag.ID) uint64 {
+	if int64(editID[0]) < 0 {
+		return -editID[0]
+	}
+	return editID[0]
+}
+
+// Revision is an edit of an element within a RevisionTree.
+type Revision struct {
+	TxOp        // the edit -- DataOfs and DataLen refer to the TxMsg the op was added from
+	Prev    int // index of the predecessor in RevisionTree.Revs (or -1 if a root or predecessor is unknown)
+	NumNext int // number of revisions whose predecessor is this revision
+}
+
+// RevisionTree reconstructs the revision history of an element (CellID, AttrID, SI) from its edits.
+//
+// Each revision is linked to its predecessor via EditFollows(), forming a tree whose leaves ("heads") are the
+// current revisions of the element.  More than one head means the element was edited concurrently, in which case
+// the most recent head (see CompareEdits) is the winner on every replica.
+type RevisionTree struct {
+	Revs []Revision
+}
+
+// AddTx adds all ops of the given tx having the given element key and returns the number of revisions added.
+func (tree *RevisionTree) AddTx(tx *TxMsg, cellID, attrID, SI tag.ID) int {
+	key := TxOp{
+		CellID: cellID,
+		AttrID: attrID,
+		SI:     SI,
+	}
+	added := 0
+	for i := range tx.Ops {
+		if sameElement(&tx.Ops[i], &key) && tree.Add(&tx.Ops[i]) {
+			added++
+		}
+	}
+	return added
+}
+
+// Add adds the given edit to this tree, returning false if it is already present.
+// The edit is linked to its predecessor and any revisions that follow it, regardless of the order edits are added.
+func (tree *RevisionTree) Add(op *TxOp) bool {
+	if tree.Find(op.EditID) >= 0 {
+		return false
+	}
+
+	idx := len(tree.Revs)
+	tree.Revs = append(tree.Revs, Revision{
+		TxOp: *op,
+		Prev: -1,
+	})
+	for i := range tree.Revs[:idx] {
+		rev := &tree.Revs[i]
+		if rev.Prev < 0 && EditFollows(rev.EditID, op.EditID) {
+			rev.Prev = idx
+			tree.Revs[idx].NumNext++
+		} else if tree.Revs[idx].Prev < 0 && EditFollows(op.EditID, rev.EditID) {
+			tree.Revs[idx].Prev = i
+			rev.NumNext++
+		}
+	}
+	return true
+}
+
+// Find returns the index of the revision having the given EditID (or -1 if not found).
+func (tree *RevisionTree) Find(editID tag.ID) int {
+	for i := range tree.Revs {
+		if tree.Revs[i].EditID == editID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Heads returns the indexes of revisions not replaced by another revision, most recent first.
+func (tree *RevisionTree) Heads() []int {
+	var heads []int
+	for i := range tree.Revs {
+		if tree.Revs[i].NumNext == 0 {
+			heads = append(heads, i)
+		}
+	}
+	sort.Slice(heads, func(i, j int) bool {
+		return CompareEdits(tree.Revs[heads[i]].EditID, tree.Revs[heads[j]].EditID) > 0
+	})
+	return heads
+}
+
+// Winner returns the index of the revision that resolves the current state of the element (or -1 if empty).
+func (tree *RevisionTree) Winner() int {
+	if heads := tree.Heads(); len(heads) > 0 {
+		return heads[0]
+	}
+	return -1
+}
+
+// History returns the index of the given revision followed by the indexes of its predecessors, oldest last.
+func (tree *RevisionTree) History(idx int) []int {
+	var history []int
+	for ; idx >= 0 && len(history) < len(tree.Revs); idx = tree.Revs[idx].Prev {
+		history = append(history, idx)
+	}
+	return history
+}
+
+// MergeReplicas returns a new tx containing the union of the ops of two replicas, sorted and without duplicates.
+// All revisions are retained, so the result is identical regardless of argument order.
+// Use Squash() on the result to resolve each element to its winning revision.
+func MergeReplicas(a, b *TxMsg) (*TxMsg, error) {
+	merged := NewTxMsg(true)
+	merged.Merge(a)
+	merged.Merge(b)
+	merged.sortOps()
+	for i := range merged.Ops {
+		if err := merged.checkOpRange(i); err != nil {
+			merged.ReleaseRef()
+			return nil, err
+		}
+	}
+
+	// Of ops having the same key, keep the one with the least value so that the result is deterministic
+	keep := 0
+	for i := range merged.Ops {
+		if keep > 0 && merged.Ops[keep-1].CompareTo(&merged.Ops[i]) == 0 {
+			if compareOpValues(merged, &merged.Ops[i], &merged.Ops[keep-1]) < 0 {
+				merged.Ops[keep-1] = merged.Ops[i]
+			}
+			continue
+		}
+		merged.Ops[keep] = merged.Ops[i]
+		keep++
+	}
+	merged.retainOps(keep)
+	return merged, nil
+}
+
+func compareOpValues(tx *TxMsg, op1, op2 *TxOp) int {
+	if op1.OpCode != op2.OpCode {
+		return int(op1.OpCode) - int(op2.OpCode)
+	}
+	return bytes.Compare(
+		tx.DataStore[op1.DataOfs:op1.DataOfs+op1.DataLen],
+		tx.DataStore[op2.DataOfs:op2.DataOfs+op2.DataLen],
+	)
+}
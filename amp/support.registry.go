@@ -125,6 +125,16 @@ func (reg *registry) GetAppForInvocation(invocation string) (*App, error) {
 }
 
 func (reg *registry) MakeValue(attrSpec tag.ID) (tag.Value, error) {
+	def, err := reg.GetAttrDef(attrSpec)
+	if err != nil {
+		return nil, err
+	}
+	return def.Prototype.New(), nil
+}
+
+func (reg *registry) GetAttrDef(attrSpec tag.ID) (AttrDef, error) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
 
 	// Often, an attrID will be a unnamed scalar attr (which means we can get the elemDef directly.
 	// This is also essential during bootstrapping when the client sends a RegisterDefs is not registered yet.
@@ -132,10 +142,10 @@ func (reg *registry) MakeValue(attrSpec tag.ID) (tag.Value, error) {
 	if !exists {
 		def, exists = reg.attrDefs[attrSpec]
 		if !exists {
-			return nil, ErrCode_AttrNotFound.Errorf("MakeValue: attr %s not found", attrSpec.String())
+			return AttrDef{}, ErrCode_AttrNotFound.Errorf("attr %s not found", attrSpec.String())
 		}
 	}
-	return def.Prototype.New(), nil
+	return def, nil
 }
 
 /*
@@ -150,147 +160,4 @@ func (reg *registry) RegisterDefs(defs *RegisterDefs) error {
 
 	return nil
 }
-
-
-func MakeSchemaForType(valTyp reflect.Type) (*AttrSchema, error) {
-	numFields := valTyp.NumField()
-
-	schema := &AttrSchema{
-		CellDataModel: valTyp.Name(),
-		SchemaName:    "on-demand-reflect",
-		Attrs:         make([]*tag.Spec, 0, numFields),
-	}
-
-	for i := 0; i < numFields; i++ {
-
-		// Importantly, TagSpecID is always set to the field index + 1, so we know what field to inspect when given an TagSpecID.
-		field := valTyp.Field(i)
-		if !field.IsExported() {
-			continue
-		}
-
-		attr := &tag.Spec{
-			TypedName: field.Name,
-			TagSpecID:  int32(i + 1),
-		}
-
-		attrType := field.Type
-		attrKind := attrType.Kind()
-		switch attrKind {
-		case reflect.Int32, reflect.Uint32, reflect.Int64, reflect.Uint64:
-			attr.ValTypeID = int32(ValType_int)
-		case reflect.String:
-			attr.ValTypeID = int32(ValType_string)
-		case reflect.Slice:
-			elementType := attrType.Elem().Kind()
-			switch elementType {
-			case reflect.Uint8, reflect.Int8:
-				attr.ValTypeID = int32(ValType_bytes)
-			}
-		}
-
-		if attr.ValTypeID == 0 {
-			return nil, ErrCode_ExportErr.Errorf("unsupported type '%s.%s (%v)", schema.CellDataModel, attr.TypedName, attrKind)
-		}
-
-		schema.Attrs = append(schema.Attrs, attr)
-	}
-	return schema, nil
-}
-
-// ReadCell loads a cell with the given URI having the inferred schema (built from its fields using reflection).
-// The URI is scoped into the user's home space and AppID.
-func ReadCell(ctx AppContext, subKey string, schema *AttrSchema, dstStruct any) error {
-
-	dst := reflect.Indirect(reflect.ValueOf(dstStruct))
-	switch dst.Kind() {
-	case reflect.Pointer:
-		dst = dst.Elem()
-	case reflect.Struct:
-	default:
-		return ErrCode_ExportErr.Errorf("expected struct, got %v", dst.Kind())
-	}
-
-	var keyBuf [128]byte
-	cellKey := append(append(keyBuf[:0], []byte(ctx.StateScope())...), []byte(subKey)...)
-
-	msgs := make([]*Msg, 0, len(schema.Attrs))
-	err := ctx.LoginInfo().HomePlanet().ReadCell(cellKey, schema, func(msg *Msg) {
-		switch msg.Op {
-		case MsgOp_PushAttr:
-			msgs = append(msgs, msg)
-		}
-	})
-	if err != nil {
-		return err
-	}
-
-	numFields := dst.NumField()
-	valType := dst.Type()
-
-	for fi := 0; fi < numFields; fi++ {
-		field := valType.Field(fi)
-		for _, ai := range schema.Attrs {
-			if ai.TypedName == field.Name {
-				for _, msg := range msgs {
-					if msg.TagSpecID == ai.TagSpecID {
-						msg.LoadVal(dst.Field(fi).Addr().Interface())
-						goto nextField
-					}
-				}
-			}
-		}
-	nextField:
-	}
-	return err
-}
-
-// WriteCell is the write analog of ReadCell.
-func WriteCell(ctx AppContext, subKey string, schema *AttrSchema, srcStruct any) error {
-
-	src := reflect.Indirect(reflect.ValueOf(srcStruct))
-	switch src.Kind() {
-	case reflect.Pointer:
-		src = src.Elem()
-	case reflect.Struct:
-	default:
-		return ErrCode_ExportErr.Errorf("expected struct, got %v", src.Kind())
-	}
-
-	{
-		tx := NewMsgBatch()
-		msg := tx.AddMsg()
-		msg.Op = MsgOp_UpsertCell
-		msg.ValType = ValType_SchemaID.Ord()
-		msg.ValInt = int64(schema.SchemaID)
-		msg.ValBuf = append(append(msg.ValBuf[:0], []byte(ctx.StateScope())...), []byte(subKey)...)
-
-		numFields := src.NumField()
-		valType := src.Type()
-
-		for _, attr := range schema.Attrs {
-			msg := tx.AddMsg()
-			msg.Op = MsgOp_PushAttr
-			msg.TagSpecID = attr.TagSpecID
-			for i := 0; i < numFields; i++ {
-				if valType.Field(i).Name == attr.TypedName {
-					msg.setVal(src.Field(i).Interface())
-					break
-				}
-			}
-			if msg.ValType == ValType_nil.Ord() {
-				panic("missing field")
-			}
-		}
-
-		msg = tx.AddMsg()
-		msg.Op = MsgOp_Commit
-
-		if err := ctx.LoginInfo().HomePlanet().PushTx(tx); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
 */
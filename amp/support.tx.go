@@ -2,6 +2,7 @@ package amp
 
 import (
 	"encoding/binary"
+	"errors"
 	"io"
 	"sort"
 	"sync"
@@ -101,6 +102,8 @@ func (tx *TxMsg) ReleaseRef() {
 	gTxMsgPool.Put(tx)
 }
 
+// MarshalAttr returns a new tx that upserts the given attr value, where the op's EditID is an edit made by the tx
+// having no predecessor (see NextEditID).
 func MarshalAttr(cellID, attrID tag.ID, attrVal tag.Value) (*TxMsg, error) {
 	tx := NewTxMsg(true)
 	if attrID.IsNil() && attrVal != nil {
@@ -112,7 +115,7 @@ func MarshalAttr(cellID, attrID tag.ID, attrVal tag.Value) (*TxMsg, error) {
 	op := TxOp{
 		CellID: cellID,
 		AttrID: attrID,
-		EditID: tx.GenesisID(),
+		EditID: tx.NextEditID(tag.ID{}),
 		OpCode: TxOpCode_UpsertElement,
 	}
 	if err := tx.MarshalOp(&op, attrVal); err != nil {
@@ -125,12 +128,78 @@ func (tx *TxMsg) UnmarshalOpValue(idx int, out tag.Value) error {
 	if idx < 0 || idx >= len(tx.Ops) {
 		return ErrCode_MalformedTx.Error("UnmarshalOpValue: index out of range")
 	}
+	if err := tx.checkOpRange(idx); err != nil {
+		return err
+	}
 	op := tx.Ops[idx]
 	ofs := op.DataOfs
 	span := tx.DataStore[ofs : ofs+op.DataLen]
 	return out.Unmarshal(span)
 }
 
+// Validate checks that this tx is well-formed, returning an ErrCode_MalformedTx *Err describing the first problem found:
+//   - GenesisID must be set,
+//   - each op must have a known OpCode other than TxOpCode_Nil,
+//   - each op's data must lie within DataStore, and
+//   - if OpsSorted is set, Ops must be in sorted order.
+//
+// A problem with a specific op is returned as a *TxOpErr -- see TxOpIndex().
+func (tx *TxMsg) Validate() error {
+	if tx.GenesisID().IsNil() {
+		return ErrCode_MalformedTx.Error("missing tx.GenesisID")
+	}
+	for i := range tx.Ops {
+		op := &tx.Ops[i]
+		if _, known := TxOpCode_name[int32(op.OpCode)]; !known || op.OpCode == TxOpCode_Nil {
+			return txOpErr(i, "unknown OpCode %d", op.OpCode)
+		}
+		if err := tx.checkOpRange(i); err != nil {
+			return err
+		}
+		if tx.OpsSorted && i > 0 && tx.Ops[i-1].CompareTo(op) > 0 {
+			return txOpErr(i, "out of order but tx.OpsSorted is set")
+		}
+	}
+	return nil
+}
+
+// checkOpRange checks that the data of the given op lies within tx.DataStore.
+func (tx *TxMsg) checkOpRange(idx int) error {
+	op := &tx.Ops[idx]
+	dataLen := uint64(len(tx.DataStore))
+	if op.DataOfs > dataLen || op.DataLen > dataLen-op.DataOfs {
+		return txOpErr(idx, "data [%d:+%d] exceeds DataStore length %d", op.DataOfs, op.DataLen, dataLen)
+	}
+	return nil
+}
+
+// TxOpErr is an ErrCode_MalformedTx *Err concerning a specific op of a TxMsg -- see TxOpIndex().
+type TxOpErr struct {
+	*Err
+	OpIndex int // index of the offending op in TxMsg.Ops
+}
+
+// Unwrap returns the underlying *Err so that GetErrCode() and errors.As() see its ErrCode.
+func (err *TxOpErr) Unwrap() error {
+	return err.Err
+}
+
+func txOpErr(idx int, format string, args ...interface{}) error {
+	return &TxOpErr{
+		Err:     ErrCode_MalformedTx.Errorf("TxOp[%d]: "+format, append([]interface{}{idx}, args...)...).(*Err),
+		OpIndex: idx,
+	}
+}
+
+// TxOpIndex returns the index of the offending op of an error returned by TxMsg.Validate() (or -1 if n/a).
+func TxOpIndex(err error) int {
+	var opErr *TxOpErr
+	if !errors.As(err, &opErr) {
+		return -1
+	}
+	return opErr.OpIndex
+}
+
 func (tx *TxMsg) LoadFirst(attrID tag.ID, dst tag.Value) error {
 	for i, op := range tx.Ops {
 		if op.AttrID == attrID {
@@ -140,22 +209,25 @@ func (tx *TxMsg) LoadFirst(attrID tag.ID, dst tag.Value) error {
 	return ErrAttrNotFound
 }
 
+// Load unmarshals the value of the op having the given CellID, AttrID, and SI into dst.
+// If more than one op has this key, the latest edit (see CompareEdits) is used.
 func (tx *TxMsg) Load(cellID, attrID, SI tag.ID, dst tag.Value) error {
 	tx.sortOps()
 
-	target := &TxOp{
+	key := &TxOp{
 		CellID: cellID,
 		AttrID: attrID,
 		SI:     SI,
 	}
-	idx, found := sort.Find(len(tx.Ops), func(i int) int {
-		return tx.Ops[i].CompareTo(target)
+	idx := sort.Search(len(tx.Ops), func(i int) bool {
+		return tx.Ops[i].CompareTo(key) >= 0
 	})
-	if !found {
+	if idx == len(tx.Ops) || !sameElement(&tx.Ops[idx], key) {
 		return ErrPropertyNotFound
 	}
 
-	return tx.UnmarshalOpValue(idx, dst)
+	_, latest := elementRun(tx, idx)
+	return tx.UnmarshalOpValue(latest, dst)
 }
 
 var (
@@ -202,12 +274,14 @@ func (tx *TxMsg) CheckMetaAttr(reg Registry) (tag.Value, error) {
 	return val, nil
 }
 
+// Upsert adds an op that upserts the given element, where the op's EditID is an edit made by this tx having no
+// predecessor (see NextEditID).
 func (tx *TxMsg) Upsert(cellID, attrID, SI tag.ID, val tag.Value) error {
 	txOp := TxOp{
 		OpCode: TxOpCode_UpsertElement,
 		CellID: cellID,
 		AttrID: attrID,
-		EditID: tx.GenesisID(),
+		EditID: tx.NextEditID(tag.ID{}),
 		SI:     SI,
 	}
 	return tx.MarshalOp(&txOp, val)
@@ -246,78 +320,19 @@ func (tx *TxMsg) MarshalOpWithBuf(op *TxOp, valBuf []byte) {
 	tx.Ops = append(tx.Ops, *op)
 }
 
+// ReadTxMsg reads a serialized TxMsg from the given stream, decoding a compressed DataStore as needed (see TxDataCodec).
+// The default limits of a TxReader are enforced.
 func ReadTxMsg(stream io.Reader) (*TxMsg, error) {
-	readBytes := func(dst []byte) error {
-		for L := 0; L < len(dst); {
-			n, err := stream.Read(dst[L:])
-			if err != nil {
-				return err
-			}
-			L += n
-		}
-		return nil
-	}
-
-	var header TxHeader
-	if err := readBytes(header[:]); err != nil {
-		return nil, err
-	}
-
-	marker := uint32(header[0])<<16 | uint32(header[1])<<8 | uint32(header[2])
-	if marker != uint32(Const_TxHeader_Marker) {
-		return nil, ErrMalformedTx
-	}
-	if header[3] < byte(Const_TxHeader_Version) {
-		return nil, ErrMalformedTx
-	}
-
-	tx := NewTxMsg(false)
-	bodyLen := header.TxBodyLen()
-	dataLen := header.TxDataLen()
-
-	// Use tx.DataStore to hold the body for unmarshalling.
-	// The tx body contains TxMsg fields and TxOps
-	{
-		needSz := max(bodyLen, dataLen)
-		if cap(tx.DataStore) < needSz {
-			tx.DataStore = make([]byte, max(needSz, 2048))
-		}
-
-		buf := tx.DataStore[:bodyLen-int(Const_TxHeader_Size)]
-		if err := readBytes(buf); err != nil {
-			return nil, err
-		}
-		if err := tx.UnmarshalBody(buf); err != nil {
-			return nil, err
-		}
-	}
-
-	// Read tx data store -- used for on-demand tag.Value unmarshalling
-	tx.DataStore = tx.DataStore[:dataLen]
-	if err := readBytes(tx.DataStore); err != nil {
-		return nil, err
-	}
-
-	return tx, nil
+	var r TxReader
+	return r.ReadTxMsg(stream)
 }
 
 func (tx *TxMsg) MarshalToWriter(scrap *[]byte, w io.Writer) (err error) {
-	writeBytes := func(src []byte) error {
-		for L := 0; L < len(src); {
-			n, err := w.Write(src[L:])
-			if err != nil {
-				return err
-			}
-			L += n
-		}
-		return nil
-	}
-
 	tx.MarshalHeaderAndOps(scrap)
-	if err = writeBytes(*scrap); err != nil {
+	if err = writeBytes(w, *scrap); err != nil {
 		return
 	}
-	if err = writeBytes(tx.DataStore); err != nil {
+	if err = writeBytes(w, tx.DataStore); err != nil {
 		return
 	}
 	return
@@ -329,6 +344,11 @@ func (tx *TxMsg) MarshalToBuffer(dst *[]byte) {
 }
 
 func (tx *TxMsg) MarshalHeaderAndOps(dst *[]byte) {
+	tx.marshalHeaderAndOps(dst, len(tx.DataStore), TxDataCodec_Raw)
+}
+
+// marshalHeaderAndOps is MarshalHeaderAndOps() for a DataStore having the given wire length and codec.
+func (tx *TxMsg) marshalHeaderAndOps(dst *[]byte, dataLen int, codec TxDataCodec) {
 	buf := (*dst)[:0]
 	if cap(buf) < 300 {
 		buf = make([]byte, 2048)
@@ -343,7 +363,11 @@ func (tx *TxMsg) MarshalHeaderAndOps(dst *[]byte) {
 	header[3] = byte(Const_TxHeader_Version)
 
 	binary.LittleEndian.PutUint32(header[4:8], uint32(len(headerAndOps)))
-	binary.LittleEndian.PutUint32(header[8:12], uint32(len(tx.DataStore)))
+	binary.LittleEndian.PutUint32(header[8:12], uint32(dataLen))
+	header[12] = byte(codec)
+	header[13] = txDataCodecsAccepted
+	header[14] = 0
+	header[15] = 0
 
 	*dst = headerAndOps
 }
@@ -416,7 +440,7 @@ func (tx *TxMsg) UnmarshalBody(src []byte) error {
 	// TxInfo
 	{
 		infoLen, n := binary.Uvarint(src[0:])
-		if n <= 0 {
+		if n <= 0 || infoLen > uint64(len(src)-n) {
 			return ErrMalformedTx
 		}
 		p += n
@@ -429,6 +453,11 @@ func (tx *TxMsg) UnmarshalBody(src []byte) error {
 		p += int(infoLen)
 	}
 
+	// Each op occupies at least 5 bytes
+	if tx.OpCount > uint64(len(src)-p)/5 {
+		return ErrCode_MalformedTx.Errorf("op count %d exceeds tx body", tx.OpCount)
+	}
+
 	var (
 		op_cur [TxField_MaxFields]uint64
 	)
@@ -439,7 +468,7 @@ func (tx *TxMsg) UnmarshalBody(src []byte) error {
 
 		// skip (future use)
 		var skip uint64
-		if skip, n = binary.Uvarint(src[p:]); n <= 0 {
+		if skip, n = binary.Uvarint(src[p:]); n <= 0 || skip > uint64(len(src)-p-n) {
 			return ErrMalformedTx
 		}
 		p += n + int(skip)
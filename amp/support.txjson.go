@@ -0,0 +1,182 @@
+package amp
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// TxJSON is the canonical JSON form of a TxMsg, intended for debugging, logging, golden-file tests, and tooling.
+// Tag IDs are in Base32 form (see tag.ID.Base32) and are omitted when nil, except for TxOpJSON.CellID and AttrID.
+type TxJSON struct {
+	Status    string     `json:"status"` // OpStatus name
+	GenesisID string     `json:"genesisID"`
+	ContextID string     `json:"contextID,omitempty"`
+	Ops       []TxOpJSON `json:"ops"`
+}
+
+// TxOpJSON is the JSON form of a TxOp and its value.
+//
+// An op value is decoded via Registry.MakeValue(AttrID) into Value.  If the registry does not know the attr (or the
+// value does not unmarshal), the value is instead given as serialized in Data so that it is preserved.
+type TxOpJSON struct {
+	OpCode string          `json:"opCode"` // TxOpCode name
+	CellID string          `json:"cellID"`
+	AttrID string          `json:"attrID"`
+	SI     string          `json:"SI,omitempty"`
+	EditID string          `json:"editID,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Data   []byte          `json:"data,omitempty"`
+}
+
+// MarshalTxJSON returns the canonical JSON form of the given tx (see TxJSON), where reg may be nil.
+func MarshalTxJSON(tx *TxMsg, reg Registry) ([]byte, error) {
+	txJSON, err := TxToJSON(tx, reg)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(txJSON, "", "  ")
+}
+
+// UnmarshalTxJSON is the inverse of MarshalTxJSON, returning a new TxMsg.
+func UnmarshalTxJSON(src []byte, reg Registry) (*TxMsg, error) {
+	var txJSON TxJSON
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&txJSON); err != nil {
+		return nil, ErrCode_MalformedTx.Errorf("UnmarshalTxJSON: %v", err)
+	}
+	return txJSON.ToTxMsg(reg)
+}
+
+// TxToJSON returns the JSON form of the given tx, where reg may be nil.
+func TxToJSON(tx *TxMsg, reg Registry) (*TxJSON, error) {
+	txJSON := &TxJSON{
+		Status:    tx.Status.String(),
+		GenesisID: idToJSON(tx.GenesisID()),
+		ContextID: idToJSON(tx.ContextID()),
+		Ops:       make([]TxOpJSON, len(tx.Ops)),
+	}
+
+	for i := range tx.Ops {
+		op := &tx.Ops[i]
+		if err := tx.checkOpRange(i); err != nil {
+			return nil, err
+		}
+		opJSON := &txJSON.Ops[i]
+		*opJSON = TxOpJSON{
+			OpCode: op.OpCode.String(),
+			CellID: op.CellID.Base32(),
+			AttrID: op.AttrID.Base32(),
+			SI:     idToJSON(op.SI),
+			EditID: idToJSON(op.EditID),
+		}
+		if op.DataLen == 0 {
+			continue
+		}
+
+		if reg != nil {
+			if val, err := reg.MakeValue(op.AttrID); err == nil && tx.UnmarshalOpValue(i, val) == nil {
+				if opJSON.Value, err = json.Marshal(val); err == nil {
+					continue
+				}
+			}
+		}
+		opJSON.Data = append([]byte{}, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+	}
+	return txJSON, nil
+}
+
+// ToTxMsg returns a new TxMsg from this JSON form, where reg is used to marshal each TxOpJSON.Value.
+// A problem with a specific op is returned as a *TxOpErr -- see TxOpIndex().
+func (txJSON *TxJSON) ToTxMsg(reg Registry) (*TxMsg, error) {
+	tx := NewTxMsg(false)
+	status, known := OpStatus_value[txJSON.Status]
+	if !known {
+		tx.ReleaseRef()
+		return nil, ErrCode_MalformedTx.Errorf("unknown tx status %q", txJSON.Status)
+	}
+	tx.Status = OpStatus(status)
+
+	var err error
+	var genesisID, contextID tag.ID
+	if genesisID, err = idFromJSON(txJSON.GenesisID); err == nil {
+		contextID, err = idFromJSON(txJSON.ContextID)
+	}
+	if err != nil {
+		tx.ReleaseRef()
+		return nil, ErrCode_MalformedTx.Wrap(err)
+	}
+	tx.SetGenesisID(genesisID)
+	tx.SetContextID(contextID)
+
+	for i := range txJSON.Ops {
+		if err := tx.unmarshalOpJSON(i, &txJSON.Ops[i], reg); err != nil {
+			tx.ReleaseRef()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+func (tx *TxMsg) unmarshalOpJSON(idx int, opJSON *TxOpJSON, reg Registry) error {
+	opCode, known := TxOpCode_value[opJSON.OpCode]
+	if !known {
+		return txOpErr(idx, "unknown OpCode %q", opJSON.OpCode)
+	}
+	op := TxOp{
+		OpCode: TxOpCode(opCode),
+	}
+	for _, field := range []struct {
+		dst *tag.ID
+		src string
+	}{
+		{&op.CellID, opJSON.CellID},
+		{&op.AttrID, opJSON.AttrID},
+		{&op.SI, opJSON.SI},
+		{&op.EditID, opJSON.EditID},
+	} {
+		id, err := idFromJSON(field.src)
+		if err != nil {
+			return txOpErr(idx, "%v", err)
+		}
+		*field.dst = id
+	}
+
+	switch {
+	case len(opJSON.Value) > 0 && len(opJSON.Data) > 0:
+		return txOpErr(idx, "value and data are mutually exclusive")
+	case len(opJSON.Value) > 0:
+		if reg == nil {
+			return txOpErr(idx, "no registry to unmarshal value")
+		}
+		val, err := reg.MakeValue(op.AttrID)
+		if err != nil {
+			return txOpErr(idx, "%v", err)
+		}
+		if err = json.Unmarshal(opJSON.Value, val); err != nil {
+			return txOpErr(idx, "%v", err)
+		}
+		return tx.MarshalOp(&op, val)
+	case len(opJSON.Data) > 0:
+		tx.MarshalOpWithBuf(&op, opJSON.Data)
+		return nil
+	default:
+		return tx.MarshalOp(&op, nil)
+	}
+}
+
+func idToJSON(id tag.ID) string {
+	if id.IsNil() {
+		return ""
+	}
+	return id.Base32()
+}
+
+func idFromJSON(str string) (tag.ID, error) {
+	if str == "" {
+		return tag.ID{}, nil
+	}
+	return tag.FromBase32(str)
+}
@@ -0,0 +1,186 @@
+package amp
+
+import (
+	"bytes"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// Merge appends the ops of src to this tx, copying src.DataStore and re-basing each op's DataOfs accordingly.
+// The TxInfo of this tx is unchanged and src is not retained.
+//
+// Use Squash() afterwards to coalesce ops that edit the same element.
+func (tx *TxMsg) Merge(src *TxMsg) {
+	if len(src.Ops) == 0 {
+		return
+	}
+
+	sorted := len(tx.Ops) == 0 && src.OpsSorted
+	base := uint64(len(tx.DataStore))
+	tx.DataStore = append(tx.DataStore, src.DataStore...)
+	for _, op := range src.Ops {
+		if op.DataLen > 0 {
+			op.DataOfs += base
+		}
+		tx.Ops = append(tx.Ops, op)
+	}
+	tx.OpCount = uint64(len(tx.Ops))
+	tx.OpsSorted = sorted
+}
+
+// Squash sorts this tx's ops and removes all but the latest op (see CompareEdits) for each (CellID, AttrID, SI) element.
+// The DataStore is compacted so that it only contains values of the remaining ops.
+//
+// An error is returned (and this tx is left sorted but otherwise unchanged) if an op's data range is invalid.
+func (tx *TxMsg) Squash() error {
+	tx.sortOps()
+	for i := range tx.Ops {
+		if err := tx.checkOpRange(i); err != nil {
+			return err
+		}
+	}
+
+	keep := 0
+	for i := 0; i < len(tx.Ops); {
+		end, latest := elementRun(tx, i)
+		tx.Ops[keep] = tx.Ops[latest]
+		keep++
+		i = end
+	}
+	tx.retainOps(keep)
+	return nil
+}
+
+// retainOps truncates this tx's ops to the first n and compacts the DataStore accordingly.
+func (tx *TxMsg) retainOps(n int) {
+	var data []byte
+	for i := 0; i < n; i++ {
+		op := &tx.Ops[i]
+		if op.DataLen > 0 {
+			start := len(data)
+			data = append(data, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen]...)
+			op.DataOfs = uint64(start)
+		}
+	}
+
+	tx.Ops = tx.Ops[:n]
+	tx.OpCount = uint64(n)
+	tx.DataStore = append(tx.DataStore[:0], data...)
+}
+
+// Diff returns a new tx that, when applied to the state described by snapshot from, yields the state described by to.
+//
+// The latest op of each (CellID, AttrID, SI) element is compared: elements whose op code or value differ (or that
+// are absent from from) are included as they appear in to, and elements of from absent from to are deleted using
+// TxOpCode_DeleteElement.  A deleted element is equivalent to an absent one and EditIDs are not compared.
+// Each op of the returned tx is an edit made by the returned tx (see TxMsg.NextEditID) so that it supersedes the
+// corresponding op of from.  The ops of from and to are sorted as needed.
+func Diff(from, to *TxMsg) (*TxMsg, error) {
+	from.sortOps()
+	to.sortOps()
+
+	diff := NewTxMsg(true)
+	diff.OpsSorted = true
+
+	for i, j := 0, 0; i < len(from.Ops) || j < len(to.Ops); {
+		iEnd, iLatest := elementRun(from, i)
+		jEnd, jLatest := elementRun(to, j)
+
+		cmp := 0
+		switch {
+		case i >= len(from.Ops):
+			cmp = 1
+		case j >= len(to.Ops):
+			cmp = -1
+		default:
+			cmp = compareElements(&from.Ops[i], &to.Ops[j])
+		}
+
+		var err error
+		switch {
+		case cmp < 0:
+			if prev := &from.Ops[iLatest]; !isDeleteOp(prev) {
+				op := *prev
+				op.OpCode = TxOpCode_DeleteElement
+				op.EditID = diff.NextEditID(prev.EditID)
+				err = diff.MarshalOp(&op, nil)
+			}
+			i = iEnd
+		case cmp > 0:
+			if !isDeleteOp(&to.Ops[jLatest]) {
+				err = diff.copyOp(to, jLatest, tag.ID{})
+			}
+			j = jEnd
+		default:
+			var same bool
+			if same, err = sameOpValue(from, iLatest, to, jLatest); err == nil && !same {
+				err = diff.copyOp(to, jLatest, from.Ops[iLatest].EditID)
+			}
+			i, j = iEnd, jEnd
+		}
+		if err != nil {
+			diff.ReleaseRef()
+			return nil, err
+		}
+	}
+	return diff, nil
+}
+
+// copyOp appends op idx of src (and its value) to this tx as an edit made by this tx replacing prevEditID.
+func (tx *TxMsg) copyOp(src *TxMsg, idx int, prevEditID tag.ID) error {
+	if err := src.checkOpRange(idx); err != nil {
+		return err
+	}
+	op := src.Ops[idx]
+	op.EditID = tx.NextEditID(prevEditID)
+	tx.MarshalOpWithBuf(&op, src.DataStore[op.DataOfs:op.DataOfs+op.DataLen])
+	return nil
+}
+
+// elementRun returns the end of the run of ops having the same element key as the op at start (where tx's ops
+// are sorted) and the index of the latest op in the run (see CompareEdits).
+func elementRun(tx *TxMsg, start int) (end, latest int) {
+	latest = start
+	for end = start + 1; end < len(tx.Ops) && sameElement(&tx.Ops[start], &tx.Ops[end]); end++ {
+		if CompareEdits(tx.Ops[end].EditID, tx.Ops[latest].EditID) > 0 {
+			latest = end
+		}
+	}
+	return end, latest
+}
+
+func sameOpValue(tx1 *TxMsg, idx1 int, tx2 *TxMsg, idx2 int) (bool, error) {
+	if err := tx1.checkOpRange(idx1); err != nil {
+		return false, err
+	}
+	if err := tx2.checkOpRange(idx2); err != nil {
+		return false, err
+	}
+	op1, op2 := &tx1.Ops[idx1], &tx2.Ops[idx2]
+	if op1.OpCode != op2.OpCode {
+		return false, nil
+	}
+	return bytes.Equal(
+		tx1.DataStore[op1.DataOfs:op1.DataOfs+op1.DataLen],
+		tx2.DataStore[op2.DataOfs:op2.DataOfs+op2.DataLen],
+	), nil
+}
+
+// compareElements is TxOp.CompareTo() but ignores EditID.
+func compareElements(op, oth *TxOp) int {
+	if diff := op.CellID.CompareTo(oth.CellID); diff != 0 {
+		return int(diff)
+	}
+	if diff := op.AttrID.CompareTo(oth.AttrID); diff != 0 {
+		return int(diff)
+	}
+	return int(op.SI.CompareTo(oth.SI))
+}
+
+func sameElement(op, oth *TxOp) bool {
+	return op.CellID == oth.CellID && op.AttrID == oth.AttrID && op.SI == oth.SI
+}
+
+func isDeleteOp(op *TxOp) bool {
+	return op.OpCode == TxOpCode_DeleteElement || op.OpCode == TxOpCode_DeleteCell
+}
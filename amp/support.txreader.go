@@ -0,0 +1,122 @@
+package amp
+
+import (
+	"io"
+)
+
+const (
+	// Default limit on the size of a serialized tx body (TxHeader and TxOps).
+	DefaultMaxTxBodyLen = 16 << 20
+
+	// Default limit on the size of a tx DataStore (after decompression).
+	DefaultMaxTxDataLen = 64 << 20
+)
+
+// TxReader reads serialized TxMsgs from untrusted sources, typically from a single connection.
+//
+// Unlike trusting the lengths in a TxHeader, a TxReader enforces size limits, reuses its buffer across reads,
+// and checks each tx via TxMsg.Validate().  A malformed tx results in an ErrCode_MalformedTx error.
+// A TxReader is not safe for concurrent use.
+type TxReader struct {
+	MaxBodyLen  int            // limit on the size of a tx body -- if 0, DefaultMaxTxBodyLen is used
+	MaxDataLen  int            // limit on the size of a tx DataStore -- if 0, DefaultMaxTxDataLen is used
+	Compression *TxCompression // if set, notified of the codecs the peer accepts (see TxCompression)
+
+	buf []byte // reused for each tx body and compressed DataStore
+}
+
+// ReadTxMsg reads the next serialized TxMsg from the given stream, decoding a compressed DataStore as needed (see TxDataCodec).
+// The returned tx is owned by the caller.
+//
+// io.EOF is returned if the stream ends before a tx starts and io.ErrUnexpectedEOF if it ends mid-tx.
+func (r *TxReader) ReadTxMsg(stream io.Reader) (*TxMsg, error) {
+	maxBodyLen := r.MaxBodyLen
+	if maxBodyLen <= 0 {
+		maxBodyLen = DefaultMaxTxBodyLen
+	}
+	maxDataLen := r.MaxDataLen
+	if maxDataLen <= 0 {
+		maxDataLen = DefaultMaxTxDataLen
+	}
+
+	var header TxHeader
+	if _, err := io.ReadFull(stream, header[:]); err != nil {
+		return nil, err
+	}
+
+	marker := uint32(header[0])<<16 | uint32(header[1])<<8 | uint32(header[2])
+	if marker != uint32(Const_TxHeader_Marker) {
+		return nil, ErrCode_MalformedTx.Error("bad TxHeader marker")
+	}
+	if header[3] < byte(Const_TxHeader_Version) {
+		return nil, ErrCode_MalformedTx.Errorf("unsupported TxHeader version %d", header[3])
+	}
+	codec := header.DataCodec()
+	if codec != TxDataCodec_Raw && codec != TxDataCodec_Deflate {
+		return nil, ErrCode_MalformedTx.Errorf("unsupported DataStore codec %d", codec)
+	}
+
+	bodyLen := header.TxBodyLen() - int(Const_TxHeader_Size)
+	if bodyLen < 0 {
+		return nil, ErrCode_MalformedTx.Errorf("bad tx body length %d", header.TxBodyLen())
+	}
+	if bodyLen > maxBodyLen {
+		return nil, ErrCode_MalformedTx.Errorf("tx body length %d exceeds limit %d", bodyLen, maxBodyLen)
+	}
+	dataLen := header.TxDataLen()
+	if dataLen > maxDataLen {
+		return nil, ErrCode_MalformedTx.Errorf("tx DataStore length %d exceeds limit %d", dataLen, maxDataLen)
+	}
+
+	// The tx body contains TxMsg fields and TxOps, which are copied out as they are unmarshalled.
+	r.buf = resizeBuf(r.buf, bodyLen)
+	if _, err := io.ReadFull(stream, r.buf); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	tx := NewTxMsg(false)
+	err := tx.UnmarshalBody(r.buf)
+
+	// Read tx data store -- used for on-demand tag.Value unmarshalling
+	if err == nil {
+		switch codec {
+		case TxDataCodec_Raw:
+			tx.DataStore = resizeBuf(tx.DataStore, dataLen)
+			_, err = io.ReadFull(stream, tx.DataStore)
+		case TxDataCodec_Deflate:
+			r.buf = resizeBuf(r.buf, dataLen)
+			if _, err = io.ReadFull(stream, r.buf); err == nil {
+				tx.DataStore, err = inflate(tx.DataStore, r.buf, maxDataLen)
+			}
+		}
+		err = unexpectedEOF(err)
+	}
+	if err == nil {
+		err = tx.Validate()
+	}
+	if err != nil {
+		tx.ReleaseRef()
+		return nil, err
+	}
+
+	if r.Compression != nil {
+		r.Compression.onHeaderRead(&header)
+	}
+	return tx, nil
+}
+
+// resizeBuf returns a slice of length n, reusing buf if it has the capacity.
+func resizeBuf(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n, max(n, 2048))
+	}
+	return buf[:n]
+}
+
+// unexpectedEOF maps io.EOF to io.ErrUnexpectedEOF since a stream ending mid-tx is not a normal close.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
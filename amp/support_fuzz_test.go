@@ -0,0 +1,199 @@
+package amp
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// Number of TxFields serialized for each op (TxField_Nil is not a field).
+const numTxFields = int(TxField_NumFields) - 1
+
+// randomTx returns a tx whose ops cycle through every valid TxOpCode and every combination of TxFields changing from the previous op.
+// So numOps >= 1<<numTxFields covers every hasFields bit combination written by MarshalOps().
+func randomTx(rng *rand.Rand, numOps int) *TxMsg {
+	tx := NewTxMsg(true)
+	tx.Status = OpStatus(rng.Intn(4))
+	tx.SetContextID(tag.ID{rng.Uint64(), rng.Uint64(), rng.Uint64()})
+
+	opCodes := make([]TxOpCode, 0, len(TxOpCode_name))
+	for code := range TxOpCode_name {
+		if TxOpCode(code) != TxOpCode_Nil {
+			opCodes = append(opCodes, TxOpCode(code))
+		}
+	}
+	sort.Slice(opCodes, func(i, j int) bool { return opCodes[i] < opCodes[j] })
+
+	var fields [TxField_MaxFields]uint64
+	for i := 0; i < numOps; i++ {
+		changed := i % (1 << numTxFields)
+		for fi := 0; fi < numTxFields; fi++ {
+			if changed&(1<<fi) != 0 {
+				fields[fi+1] = rng.Uint64()
+			}
+		}
+
+		op := TxOp{
+			OpCode: opCodes[i%len(opCodes)],
+			CellID: tag.ID{fields[TxField_CellID_0], fields[TxField_CellID_1], fields[TxField_CellID_2]},
+			AttrID: tag.ID{fields[TxField_AttrID_0], fields[TxField_AttrID_1], fields[TxField_AttrID_2]},
+			SI:     tag.ID{fields[TxField_SI_0], fields[TxField_SI_1], fields[TxField_SI_2]},
+			EditID: tag.ID{fields[TxField_EditID_0], fields[TxField_EditID_1]},
+		}
+		data := make([]byte, rng.Intn(64))
+		rng.Read(data)
+		tx.MarshalOpWithBuf(&op, data)
+	}
+	return tx
+}
+
+func checkTxEqual(t *testing.T, tx1, tx2 *TxMsg) {
+	t.Helper()
+
+	if tx1.TxInfo != tx2.TxInfo {
+		t.Fatalf("TxInfo mismatch")
+	}
+	if len(tx1.Ops) != len(tx2.Ops) {
+		t.Fatalf("expected %d ops, got %d", len(tx1.Ops), len(tx2.Ops))
+	}
+	for i := range tx1.Ops {
+		op1, op2 := tx1.Ops[i], tx2.Ops[i]
+		op1.EditID[2], op2.EditID[2] = 0, 0 // EditID is truncated to 16 bytes on the wire
+		if op1 != op2 {
+			t.Fatalf("TxOp %d mismatch", i)
+		}
+	}
+	if !bytes.Equal(tx1.DataStore, tx2.DataStore) {
+		t.Fatalf("DataStore mismatch")
+	}
+}
+
+func TestTxRoundTrip(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		numOps := rng.Intn(300)
+		if seed == 1 {
+			numOps = 1 << numTxFields
+		}
+		tx := randomTx(rng, numOps)
+
+		// Raw and compressed encodings
+		comp := TxCompression{
+			Codec:   TxDataCodec_Deflate,
+			MinSize: 1,
+		}
+		comp.peerAccepts.Store(txDataCodecsAccepted)
+		for _, marshal := range []func(dst *[]byte){
+			func(dst *[]byte) { tx.MarshalToBuffer(dst) },
+			func(dst *[]byte) { comp.MarshalToBuffer(tx, dst) },
+		} {
+			var buf []byte
+			marshal(&buf)
+			tx2, err := ReadTxMsg(bytes.NewReader(buf))
+			if err != nil {
+				t.Fatalf("seed %d: ReadTxMsg failed: %v", seed, err)
+			}
+			checkTxEqual(t, tx, tx2)
+			tx2.ReleaseRef()
+		}
+		tx.ReleaseRef()
+	}
+}
+
+// addSeedTxs adds serialized random txs as seed inputs.
+func addSeedTxs(f *testing.F) {
+	rng := rand.New(rand.NewSource(3773))
+	for _, numOps := range []int{0, 1, 5, 40} {
+		var buf []byte
+		randomTx(rng, numOps).MarshalToBuffer(&buf)
+		f.Add(buf)
+	}
+}
+
+// fuzzReader limits allocations while fuzzing.
+func fuzzReader() *TxReader {
+	return &TxReader{
+		MaxBodyLen: 1 << 20,
+		MaxDataLen: 1 << 20,
+	}
+}
+
+func FuzzReadTxMsg(f *testing.F) {
+	addSeedTxs(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx, err := fuzzReader().ReadTxMsg(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		// A tx that reads successfully must survive a round trip
+		var buf []byte
+		tx.MarshalToBuffer(&buf)
+		tx2, err := fuzzReader().ReadTxMsg(bytes.NewReader(buf))
+		if err != nil {
+			t.Fatalf("re-read failed: %v", err)
+		}
+		checkTxEqual(t, tx, tx2)
+
+		// Every op value is within bounds
+		for i := range tx.Ops {
+			tx.UnmarshalOpValue(i, &Tag{})
+		}
+	})
+}
+
+func FuzzUnmarshalBody(f *testing.F) {
+	addSeedTxs(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) >= int(Const_TxHeader_Size) {
+			data = data[Const_TxHeader_Size:]
+		}
+		tx := NewTxMsg(false)
+		if err := tx.UnmarshalBody(data); err != nil {
+			return
+		}
+
+		// Re-marshalling yields a body that unmarshals identically
+		body := tx.MarshalOps(nil)
+		tx2 := NewTxMsg(false)
+		if err := tx2.UnmarshalBody(body); err != nil {
+			t.Fatalf("re-unmarshal failed: %v", err)
+		}
+		checkTxEqual(t, tx, tx2)
+
+		// Op values are bounds-checked even when the tx has not been validated
+		for i := range tx.Ops {
+			tx.UnmarshalOpValue(i, &Tag{})
+		}
+	})
+}
+
+func FuzzLoad(f *testing.F) {
+	addSeedTxs(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx, err := fuzzReader().ReadTxMsg(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		// Every op key is found, and an absent key is not
+		ops := append([]TxOp(nil), tx.Ops...)
+		for _, op := range ops {
+			if err = tx.Load(op.CellID, op.AttrID, op.SI, &Tag{}); err == ErrPropertyNotFound {
+				t.Fatalf("Load failed to find op key")
+			}
+		}
+		absent := tag.ID{0, 0, 0x3773}
+		for _, op := range ops {
+			if op.CellID == absent {
+				return
+			}
+		}
+		if err = tx.Load(absent, tag.ID{}, tag.ID{}, &Tag{}); err != ErrPropertyNotFound {
+			t.Fatalf("expected ErrPropertyNotFound, got %v", err)
+		}
+	})
+}
@@ -2,10 +2,12 @@ package amp
 
 import (
 	"bytes"
+	"encoding/binary"
 	fmt "fmt"
 	io "io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
 )
@@ -92,6 +94,273 @@ func TestTxSerialize(t *testing.T) {
 	}
 }
 
+func TestTxCompression(t *testing.T) {
+	tx := NewTxMsg(true)
+	data := bytes.Repeat([]byte("hello-world"), 100)
+	for i := 0; i < 100; i++ {
+		attrID := (&LoginResponse{}).TagSpec().ID
+		if err := tx.Upsert(tag.ID{0, 0, 1}, attrID, tag.ID{0, 0, uint64(i)}, &LoginResponse{HashResponse: data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sender := TxCompression{
+		Codec: TxDataCodec_Deflate,
+	}
+	receiver := TxCompression{}
+
+	// Until the peer advertises it accepts a codec, txs are sent raw
+	var raw []byte
+	sender.MarshalToBuffer(tx, &raw)
+	header := TxHeader(raw[:Const_TxHeader_Size])
+	if header.DataCodec() != TxDataCodec_Raw || !header.Accepts(TxDataCodec_Deflate) {
+		t.Fatalf("unexpected header codecs: %v", header[12:])
+	}
+
+	// Once the sender reads a tx from its peer, it compresses
+	if _, err := receiver.ReadTxMsg(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	var reply []byte
+	receiver.MarshalToBuffer(NewTxMsg(true), &reply)
+	if _, err := sender.ReadTxMsg(bytes.NewReader(reply)); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed []byte
+	sender.MarshalToBuffer(tx, &compressed)
+	header = TxHeader(compressed[:Const_TxHeader_Size])
+	if header.DataCodec() != TxDataCodec_Deflate || len(compressed) > len(raw)/4 {
+		t.Fatalf("expected compressed tx: codec %v, %d vs %d bytes", header.DataCodec(), len(compressed), len(raw))
+	}
+	tx2, err := ReadTxMsg(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("ReadTxMsg failed: %v", err)
+	}
+	if len(tx2.Ops) != len(tx.Ops) || !bytes.Equal(tx.DataStore, tx2.DataStore) {
+		t.Fatalf("compressed tx mismatch")
+	}
+	resp := LoginResponse{}
+	if err = tx2.UnmarshalOpValue(99, &resp); err != nil || !bytes.Equal(resp.HashResponse, data) {
+		t.Fatalf("UnmarshalOpValue failed: %v", err)
+	}
+
+	// A small DataStore is not worth compressing
+	small := NewTxMsg(true)
+	small.Upsert(tag.ID{0, 0, 1}, resp.TagSpec().ID, tag.ID{}, &LoginResponse{HashResponse: []byte("hi")})
+	sender.MarshalToBuffer(small, &compressed)
+	if TxHeader(compressed[:Const_TxHeader_Size]).DataCodec() != TxDataCodec_Raw {
+		t.Errorf("expected small tx to be sent raw")
+	}
+
+	// Unknown codecs are rejected
+	compressed[12] = 0x7F
+	if _, err = ReadTxMsg(bytes.NewReader(compressed)); err == nil {
+		t.Errorf("expected unsupported codec to fail")
+	}
+
+	// Inflated data must have the declared length
+	deflated := appendDeflate(nil, data)
+	if out, err := inflate(make([]byte, 10), deflated, len(data)); err != nil || !bytes.Equal(out, data) {
+		t.Fatalf("inflate failed: %v", err)
+	}
+	_, n := binary.Uvarint(deflated)
+	for _, declared := range []int{len(data) - 1, len(data) + 1, 1 << 30} {
+		bad := append(binary.AppendUvarint(nil, uint64(declared)), deflated[n:]...)
+		if _, err = inflate(nil, bad, 1<<30); GetErrCode(err) != ErrCode_MalformedTx {
+			t.Errorf("declared length %d: expected ErrCode_MalformedTx, got %v", declared, err)
+		}
+	}
+}
+
+func TestTxReader(t *testing.T) {
+	makeTx := func(n int) []byte {
+		tx := NewTxMsg(true)
+		for i := 0; i < n; i++ {
+			tx.Upsert(tag.ID{0, 0, 1}, (&Tag{}).TagSpec().ID, tag.ID{0, 0, uint64(i)}, &Tag{Text: "tx op"})
+		}
+		var buf []byte
+		tx.MarshalToBuffer(&buf)
+		return buf
+	}
+
+	// Successive txs are read from the same stream, reusing the reader's buffer
+	var stream []byte
+	for i := 1; i <= 3; i++ {
+		stream = append(stream, makeTx(i*10)...)
+	}
+	r := TxReader{}
+	in := bytes.NewReader(stream)
+	for i := 1; i <= 3; i++ {
+		tx, err := r.ReadTxMsg(in)
+		if err != nil {
+			t.Fatalf("ReadTxMsg failed: %v", err)
+		}
+		if len(tx.Ops) != i*10 {
+			t.Fatalf("expected %d ops, got %d", i*10, len(tx.Ops))
+		}
+		tx.ReleaseRef()
+	}
+	if _, err := r.ReadTxMsg(in); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	checkMalformed := func(name string, r *TxReader, buf []byte) {
+		t.Helper()
+		_, err := r.ReadTxMsg(bytes.NewReader(buf))
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if GetErrCode(err) != ErrCode_MalformedTx {
+			if err != io.ErrUnexpectedEOF {
+				t.Fatalf("%s: unexpected error %v", name, err)
+			}
+		}
+	}
+
+	txBuf := makeTx(10)
+	header := TxHeader(txBuf[:Const_TxHeader_Size])
+
+	checkMalformed("body limit", &TxReader{MaxBodyLen: header.TxBodyLen() - 17}, txBuf)
+	checkMalformed("data limit", &TxReader{MaxDataLen: header.TxDataLen() - 1}, txBuf)
+	checkMalformed("truncated", &TxReader{}, txBuf[:len(txBuf)-1])
+
+	bad := append([]byte(nil), txBuf...)
+	bad[0] = 'x'
+	checkMalformed("marker", &TxReader{}, bad)
+
+	// Claim a DataStore shorter than the ops reference
+	bad = append([]byte(nil), txBuf[:len(txBuf)-1]...)
+	binary.LittleEndian.PutUint32(bad[8:12], uint32(header.TxDataLen()-1))
+	checkMalformed("op range", &TxReader{}, bad)
+
+	// Claim a huge TxInfo length
+	bad = append([]byte(nil), txBuf...)
+	bad[Const_TxHeader_Size] = 0x7F
+	checkMalformed("TxInfo length", &TxReader{}, bad)
+}
+
+func TestTxValidate(t *testing.T) {
+	makeTx := func() *TxMsg {
+		tx := NewTxMsg(true)
+		for i := 0; i < 3; i++ {
+			tx.Upsert(tag.ID{0, 0, 1}, (&Tag{}).TagSpec().ID, tag.ID{0, 0, uint64(i)}, &Tag{Text: "tx op"})
+		}
+		return tx
+	}
+	if err := makeTx().Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(tx *TxMsg)
+		opIdx  int
+	}{
+		{"GenesisID", func(tx *TxMsg) { tx.SetGenesisID(tag.ID{}) }, -1},
+		{"OpCode", func(tx *TxMsg) { tx.Ops[1].OpCode = 77 }, 1},
+		{"Nil OpCode", func(tx *TxMsg) { tx.Ops[0].OpCode = TxOpCode_Nil }, 0},
+		{"DataOfs", func(tx *TxMsg) { tx.Ops[2].DataOfs = uint64(len(tx.DataStore)) + 1 }, 2},
+		{"DataLen", func(tx *TxMsg) { tx.Ops[2].DataLen = ^uint64(0) }, 2},
+		{"OpsSorted", func(tx *TxMsg) { tx.Ops[0], tx.Ops[1] = tx.Ops[1], tx.Ops[0]; tx.OpsSorted = true }, 1},
+	}
+	for _, test := range tests {
+		tx := makeTx()
+		test.mutate(tx)
+		err := tx.Validate()
+		if GetErrCode(err) != ErrCode_MalformedTx {
+			t.Errorf("%s: expected ErrCode_MalformedTx, got %v", test.name, err)
+		}
+		if idx := TxOpIndex(err); idx != test.opIdx {
+			t.Errorf("%s: expected op index %d, got %d", test.name, test.opIdx, idx)
+		}
+	}
+
+	// A bad op range is reported rather than panicking
+	tx := makeTx()
+	tx.Ops[1].DataLen = 1 << 40
+	if err := tx.UnmarshalOpValue(1, &Tag{}); TxOpIndex(err) != 1 {
+		t.Errorf("expected UnmarshalOpValue to fail, got %v", err)
+	}
+
+	// The op index is carried by the error rather than parsed from its message
+	if idx := TxOpIndex(ErrCode_MalformedTx.Error("TxOp[3]: not an op error")); idx != -1 {
+		t.Errorf("expected op index -1, got %d", idx)
+	}
+	tx.Ops[1].OpCode = 77
+	if errVal, _ := ErrorToValue(tx.Validate()).(*Err); errVal == nil || errVal.Code != ErrCode_MalformedTx {
+		t.Errorf("expected ErrorToValue to keep ErrCode_MalformedTx, got %v", errVal)
+	}
+}
+
+func TestTxJSON(t *testing.T) {
+	reg := NewRegistry()
+	RegisterBuiltinTypes(reg)
+
+	tx := NewTxMsg(true)
+	tx.Status = OpStatus_Synced
+	tx.SetContextID(tag.ID{0, 0, 777})
+	cellID := tag.ID{0, 0, 1}
+	tx.Upsert(cellID, (&Login{}).TagSpec().ID, tag.ID{}, &Login{
+		UserLabel: "lil turkey",
+		UserUID: &Tag{
+			Text: "cmdr5",
+		},
+	})
+	tx.MarshalOpWithBuf(&TxOp{
+		OpCode: TxOpCode_UpsertElement,
+		CellID: cellID,
+		AttrID: tag.ID{0, 0, 4242}, // not registered
+		SI:     tag.ID{0, 0, 2},
+		EditID: tx.NextEditID(tag.ID{}),
+	}, []byte("opaque"))
+	tx.MarshalOp(&TxOp{
+		OpCode: TxOpCode_DeleteElement,
+		CellID: cellID,
+		AttrID: (&Tag{}).TagSpec().ID,
+		SI:     tag.ID{0, 0, 3},
+		EditID: tx.NextEditID(tag.ID{}),
+	}, nil)
+
+	buf, err := MarshalTxJSON(tx, reg)
+	if err != nil {
+		t.Fatalf("MarshalTxJSON failed: %v", err)
+	}
+	for _, want := range []string{
+		`"status": "OpStatus_Synced"`,
+		`"cellID": "` + cellID.Base32() + `"`,
+		`"UserLabel": "lil turkey"`,
+		`"opCode": "TxOpCode_DeleteElement"`,
+	} {
+		if !bytes.Contains(buf, []byte(want)) {
+			t.Fatalf("expected JSON to contain %s:\n%s", want, buf)
+		}
+	}
+
+	tx2, err := UnmarshalTxJSON(buf, reg)
+	if err != nil {
+		t.Fatalf("UnmarshalTxJSON failed: %v", err)
+	}
+	if tx2.TxInfo != tx.TxInfo || !reflect.DeepEqual(tx2.Ops, tx.Ops) || !bytes.Equal(tx2.DataStore, tx.DataStore) {
+		t.Fatalf("UnmarshalTxJSON did not restore tx:\n%s", buf)
+	}
+
+	// Without a registry, values are given as serialized
+	buf, err = MarshalTxJSON(tx, nil)
+	if err != nil || bytes.Contains(buf, []byte("lil turkey")) {
+		t.Fatalf("MarshalTxJSON without registry failed: %v", err)
+	}
+	if tx2, err = UnmarshalTxJSON(buf, nil); err != nil || !bytes.Equal(tx2.DataStore, tx.DataStore) {
+		t.Fatalf("UnmarshalTxJSON without registry failed: %v", err)
+	}
+
+	// A bad op is reported by index
+	bad := bytes.Replace(buf, []byte("TxOpCode_DeleteElement"), []byte("TxOpCode_Bogus"), 1)
+	if _, err = UnmarshalTxJSON(bad, reg); TxOpIndex(err) != 2 {
+		t.Fatalf("expected bad OpCode at TxOp[2], got %v", err)
+	}
+}
+
 type bufReader struct {
 	buf []byte
 	pos int
@@ -106,6 +375,408 @@ func (r *bufReader) Read(p []byte) (n int, err error) {
 	return n, nil
 }
 
+func TestTxLoad(t *testing.T) {
+	cellID := tag.ID{0, 0, 1}
+	attrID := (&Tag{}).TagSpec().ID
+	tx := NewTxMsg(true)
+	for _, edit := range []struct {
+		SI     uint64
+		editID tag.ID
+		text   string
+	}{
+		{2, tag.ID{0, 2}, "two (edited)"},
+		{1, tag.ID{0, 1}, "one"},
+		{2, tag.ID{0, 1}, "two"},
+		{2, tag.ID{}, "two (no EditID)"},
+		{3, tag.ID{1}, "three"},
+	} {
+		op := TxOp{
+			OpCode: TxOpCode_UpsertElement,
+			CellID: cellID,
+			AttrID: attrID,
+			SI:     tag.ID{0, 0, edit.SI},
+			EditID: edit.editID,
+		}
+		tx.MarshalOp(&op, &Tag{Text: edit.text})
+	}
+
+	// The op having the greatest EditID is loaded, regardless of the order of the ops
+	for SI, want := range map[uint64]string{
+		1: "one",
+		2: "two (edited)",
+		3: "three",
+	} {
+		val := Tag{}
+		if err := tx.Load(cellID, attrID, tag.ID{0, 0, SI}, &val); err != nil || val.Text != want {
+			t.Errorf("SI %d: expected %q, got %q (%v)", SI, want, val.Text, err)
+		}
+	}
+
+	for _, SI := range []tag.ID{{}, {0, 0, 4}, {1}} {
+		if err := tx.Load(cellID, attrID, SI, &Tag{}); err != ErrPropertyNotFound {
+			t.Errorf("SI %v: expected ErrPropertyNotFound, got %v", SI, err)
+		}
+	}
+	if err := tx.Load(tag.ID{0, 0, 2}, attrID, tag.ID{0, 0, 1}, &Tag{}); err != ErrPropertyNotFound {
+		t.Errorf("expected ErrPropertyNotFound, got %v", err)
+	}
+}
+
+func TestTxMerge(t *testing.T) {
+	cellID := tag.ID{0, 0, 1}
+	attrID := (&Tag{}).TagSpec().ID
+	upsert := func(tx *TxMsg, SI, editID uint64, text string) {
+		op := TxOp{
+			OpCode: TxOpCode_UpsertElement,
+			CellID: cellID,
+			AttrID: attrID,
+			SI:     tag.ID{0, 0, SI},
+			EditID: tag.ID{0, editID},
+		}
+		tx.MarshalOp(&op, &Tag{Text: text})
+	}
+	load := func(tx *TxMsg, SI uint64) string {
+		val := Tag{}
+		if err := tx.Load(cellID, attrID, tag.ID{0, 0, SI}, &val); err != nil {
+			return ""
+		}
+		return val.Text
+	}
+
+	tx1 := NewTxMsg(true)
+	upsert(tx1, 1, 1, "one")
+	upsert(tx1, 2, 1, "two")
+	tx2 := NewTxMsg(true)
+	upsert(tx2, 2, 3, "two (edited)")
+	upsert(tx2, 3, 2, "three")
+	upsert(tx2, 1, 0, "one (stale)")
+
+	// Merge
+	merged := NewTxMsg(true)
+	merged.Merge(tx1)
+	merged.Merge(tx2)
+	if len(merged.Ops) != 5 || merged.OpCount != 5 {
+		t.Fatalf("expected 5 merged ops, got %d", len(merged.Ops))
+	}
+	if err := merged.Validate(); err != nil {
+		t.Fatalf("merged tx is invalid: %v", err)
+	}
+	for SI, want := range map[uint64]string{1: "one", 2: "two (edited)", 3: "three"} {
+		if got := load(merged, SI); got != want {
+			t.Errorf("merged SI %d: expected %q, got %q", SI, want, got)
+		}
+	}
+
+	// Squash
+	if err := merged.Squash(); err != nil {
+		t.Fatalf("Squash failed: %v", err)
+	}
+	if len(merged.Ops) != 3 || merged.OpCount != 3 {
+		t.Fatalf("expected 3 squashed ops, got %d", len(merged.Ops))
+	}
+	for SI, want := range map[uint64]string{1: "one", 2: "two (edited)", 3: "three"} {
+		if got := load(merged, SI); got != want {
+			t.Errorf("squashed SI %d: expected %q, got %q", SI, want, got)
+		}
+	}
+	var buf []byte
+	merged.MarshalToBuffer(&buf)
+	if tx, err := ReadTxMsg(bytes.NewReader(buf)); err != nil {
+		t.Fatalf("squashed tx failed to round trip: %v", err)
+	} else if load(tx, 2) != "two (edited)" {
+		t.Errorf("squashed tx round trip mismatch")
+	}
+
+	// Diff
+	diff, err := Diff(tx1, merged)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(diff.Ops) != 2 || load(diff, 2) != "two (edited)" || load(diff, 3) != "three" {
+		t.Errorf("unexpected diff: %v", diff.Ops)
+	}
+	diff, err = Diff(merged, tx1)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(diff.Ops) != 2 || load(diff, 2) != "two" || diff.Ops[1].OpCode != TxOpCode_DeleteElement || diff.Ops[1].SI[2] != 3 {
+		t.Errorf("unexpected reverse diff: %v", diff.Ops)
+	}
+
+	// Applying a diff to its source yields the target
+	merged.Merge(diff)
+	if err = merged.Squash(); err != nil {
+		t.Fatalf("Squash failed: %v", err)
+	}
+	if diff, _ = Diff(tx1, merged); len(diff.Ops) != 0 {
+		t.Errorf("expected empty diff, got %v", diff.Ops)
+	}
+}
+
+func TestCellStore(t *testing.T) {
+	store := NewCellStore()
+	cell1, cell2 := tag.ID{0, 0, 1}, tag.ID{0, 0, 2}
+	attrID := (&Tag{}).TagSpec().ID
+
+	apply := func(ops ...TxOp) {
+		tx := NewTxMsg(true)
+		for _, op := range ops {
+			var val tag.Value
+			if op.OpCode == TxOpCode_UpsertElement {
+				val = &Tag{Text: fmt.Sprintf("%d.%d.%d", op.CellID[2], op.SI[2], op.EditID[1])}
+			}
+			tx.MarshalOp(&op, val)
+		}
+		if err := store.Apply(tx); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		tx.ReleaseRef()
+	}
+	op := func(code TxOpCode, cellID tag.ID, SI, editID uint64) TxOp {
+		return TxOp{
+			OpCode: code,
+			CellID: cellID,
+			AttrID: attrID,
+			SI:     tag.ID{0, 0, SI},
+			EditID: tag.ID{0, editID},
+		}
+	}
+	load := func(cellID tag.ID, SI uint64) string {
+		val := Tag{}
+		if err := store.Load(cellID, attrID, tag.ID{0, 0, SI}, &val); err != nil {
+			return ""
+		}
+		return val.Text
+	}
+	scan := func(cellID tag.ID, minSI, maxSI tag.ID) (texts []string) {
+		store.Scan(cellID, attrID, minSI, maxSI, func(op *TxOp, data []byte) bool {
+			val := Tag{}
+			val.Unmarshal(data)
+			texts = append(texts, val.Text)
+			return true
+		})
+		return texts
+	}
+
+	apply(
+		op(TxOpCode_UpsertElement, cell1, 3, 1),
+		op(TxOpCode_UpsertElement, cell1, 1, 1),
+		op(TxOpCode_UpsertElement, cell1, 2, 1),
+		op(TxOpCode_UpsertElement, cell2, 1, 1),
+	)
+	apply(
+		op(TxOpCode_UpsertElement, cell1, 2, 5),
+		op(TxOpCode_UpsertElement, cell1, 1, 0), // older than the current edit
+		op(TxOpCode_DeleteElement, cell1, 3, 2),
+		op(TxOpCode_UpsertElement, cell1, 3, 1), // older than the deletion
+	)
+	for SI, want := range map[uint64]string{1: "1.1.1", 2: "1.2.5", 3: ""} {
+		if got := load(cell1, SI); got != want {
+			t.Errorf("SI %d: expected %q, got %q", SI, want, got)
+		}
+	}
+
+	// Range scans
+	if got := fmt.Sprint(scan(cell1, tag.ID{}, tag.ID{})); got != "[1.1.1 1.2.5]" {
+		t.Errorf("unexpected full scan: %v", got)
+	}
+	if got := fmt.Sprint(scan(cell1, tag.ID{0, 0, 2}, tag.ID{0, 0, 3})); got != "[1.2.5]" {
+		t.Errorf("unexpected range scan: %v", got)
+	}
+
+	// Snapshot
+	snap := NewTxMsg(true)
+	store.Snapshot(snap)
+	if len(snap.Ops) != 3 || snap.Ops[0].CellID != cell1 || snap.Ops[2].CellID != cell2 {
+		t.Fatalf("unexpected snapshot: %v", snap.Ops)
+	}
+	if err := snap.Validate(); err != nil {
+		t.Fatalf("snapshot is invalid: %v", err)
+	}
+	copied := NewCellStore()
+	if err := copied.Apply(snap); err != nil {
+		t.Fatalf("Apply(snapshot) failed: %v", err)
+	}
+	val := Tag{}
+	if err := copied.Load(cell1, attrID, tag.ID{0, 0, 2}, &val); err != nil || val.Text != "1.2.5" {
+		t.Errorf("snapshot mismatch: %q %v", val.Text, err)
+	}
+	snap2 := NewTxMsg(true)
+	store.Snapshot(snap2, cell2)
+	if len(snap2.Ops) != 1 || snap2.Ops[0].CellID != cell2 {
+		t.Errorf("unexpected cell snapshot: %v", snap2.Ops)
+	}
+
+	// DeleteCell only removes edits up to its EditID
+	apply(
+		op(TxOpCode_UpsertElement, cell1, 4, 9),
+		TxOp{OpCode: TxOpCode_DeleteCell, CellID: cell1, EditID: tag.ID{0, 7}},
+		op(TxOpCode_UpsertElement, cell1, 5, 6),
+	)
+	if got := fmt.Sprint(scan(cell1, tag.ID{}, tag.ID{})); got != "[1.4.9]" {
+		t.Errorf("unexpected scan after DeleteCell: %v", got)
+	}
+
+	// A deletion having the same EditID as an upsert wins, regardless of the order they are applied
+	deleteCell := TxOp{OpCode: TxOpCode_DeleteCell, CellID: cell2, EditID: tag.ID{0, 20}}
+	apply(op(TxOpCode_UpsertElement, cell2, 2, 20), deleteCell)
+	apply(deleteCell, op(TxOpCode_UpsertElement, cell2, 3, 20))
+	apply(op(TxOpCode_UpsertElement, cell2, 4, 21), op(TxOpCode_DeleteElement, cell2, 4, 21))
+	apply(op(TxOpCode_DeleteElement, cell2, 5, 21), op(TxOpCode_UpsertElement, cell2, 5, 21))
+	if got := fmt.Sprint(scan(cell2, tag.ID{}, tag.ID{})); got != "[]" {
+		t.Errorf("expected deletions to win, got %v", got)
+	}
+}
+
+func TestEditIDs(t *testing.T) {
+	t1 := tag.ID{100 << 16, 1}
+	t2 := tag.ID{200 << 16, 2}
+
+	root := NewEditID(t1, tag.ID{})
+	edit := NewEditID(t2, root)
+	if !EditFollows(root, tag.ID{}) || !EditFollows(edit, root) {
+		t.Fatalf("EditFollows failed")
+	}
+	if EditFollows(root, edit) || EditFollows(edit, tag.ID{}) || EditFollows(t2, root) {
+		t.Fatalf("EditFollows false positive")
+	}
+	if CompareEdits(edit, root) <= 0 || CompareEdits(root, edit) >= 0 || CompareEdits(edit, edit) != 0 {
+		t.Fatalf("CompareEdits failed")
+	}
+	if edit.CompareTo(root) >= 0 {
+		t.Fatalf("expected newer EditIDs to sort first")
+	}
+
+	// An edit made by a tx that is older than its predecessor is still more recent
+	skewed := NewEditID(t1, edit)
+	if !EditFollows(skewed, edit) || CompareEdits(skewed, edit) <= 0 {
+		t.Fatalf("clock skew not handled")
+	}
+
+	// Edits made by two replicas at the same time are distinct and resolve the same on either replica
+	now := time.Now()
+	replicaA, replicaB := NewTxMsg(false), NewTxMsg(false)
+	replicaA.SetGenesisID(tag.FromTime(now, true))
+	replicaB.SetGenesisID(tag.FromTime(now, true))
+	editA, editB := replicaA.NextEditID(root), replicaB.NextEditID(root)
+	if editA == editB || editA[0] != editB[0] {
+		t.Fatalf("expected distinct EditIDs made at the same time")
+	}
+	if !EditFollows(editA, root) || !EditFollows(editB, root) {
+		t.Fatalf("EditFollows failed for concurrent edits")
+	}
+	if CompareEdits(editA, editB) == 0 || CompareEdits(editA, editB) != -CompareEdits(editB, editA) {
+		t.Fatalf("CompareEdits failed for concurrent edits")
+	}
+	var winners []string
+	for _, pair := range [][2]*TxMsg{{replicaA, replicaB}, {replicaB, replicaA}} {
+		for _, replica := range []*TxMsg{replicaA, replicaB} {
+			replica.Ops, replica.DataStore = replica.Ops[:0], replica.DataStore[:0]
+			text := "A"
+			if replica == replicaB {
+				text = "B"
+			}
+			replica.MarshalOp(&TxOp{OpCode: TxOpCode_UpsertElement, CellID: tag.ID{0, 0, 1}, EditID: replica.NextEditID(root)}, &Tag{Text: text})
+		}
+		merged, err := MergeReplicas(pair[0], pair[1])
+		if err != nil || len(merged.Ops) != 2 {
+			t.Fatalf("expected both concurrent edits to be merged: %v", err)
+		}
+		if err = merged.Squash(); err != nil {
+			t.Fatalf("Squash failed: %v", err)
+		}
+		val := Tag{}
+		merged.Load(tag.ID{0, 0, 1}, tag.ID{}, tag.ID{}, &val)
+		winners = append(winners, val.Text)
+	}
+	if winners[0] == "" || winners[0] != winners[1] {
+		t.Fatalf("concurrent edits resolved differently: %v", winners)
+	}
+
+	// Derived EditIDs compare by time with EditIDs copied from a GenesisID
+	if CompareEdits(edit, t1) <= 0 || CompareEdits(t1, edit) >= 0 {
+		t.Fatalf("CompareEdits failed for a GenesisID")
+	}
+
+	// EditIDs survive serialization
+	tx := NewTxMsg(true)
+	op := TxOp{OpCode: TxOpCode_UpsertElement, CellID: tag.ID{0, 0, 1}, EditID: edit}
+	tx.MarshalOp(&op, nil)
+	var buf []byte
+	tx.MarshalToBuffer(&buf)
+	tx2, err := ReadTxMsg(bytes.NewReader(buf))
+	if err != nil || tx2.Ops[0].EditID != edit {
+		t.Fatalf("EditID round trip failed: %v", err)
+	}
+}
+
+func TestRevisionTree(t *testing.T) {
+	cellID := tag.ID{0, 0, 1}
+	attrID := (&Tag{}).TagSpec().ID
+	edit := func(tx *TxMsg, txTime uint64, prev tag.ID, text string) tag.ID {
+		op := TxOp{
+			OpCode: TxOpCode_UpsertElement,
+			CellID: cellID,
+			AttrID: attrID,
+			EditID: NewEditID(tag.ID{txTime << 16}, prev),
+		}
+		tx.MarshalOp(&op, &Tag{Text: text})
+		return op.EditID
+	}
+
+	// Both replicas share a root revision and then edit concurrently
+	replicaA := NewTxMsg(true)
+	replicaB := NewTxMsg(true)
+	root := edit(replicaA, 1, tag.ID{}, "root")
+	edit(replicaB, 1, tag.ID{}, "root")
+	a1 := edit(replicaA, 2, root, "a1")
+	a2 := edit(replicaA, 5, a1, "a2")
+	b1 := edit(replicaB, 3, root, "b1")
+
+	tree := RevisionTree{}
+	if n := tree.AddTx(replicaB, cellID, attrID, tag.ID{}); n != 2 {
+		t.Fatalf("expected 2 revisions, got %d", n)
+	}
+	if n := tree.AddTx(replicaA, cellID, attrID, tag.ID{}); n != 2 {
+		t.Fatalf("expected 2 more revisions, got %d", n)
+	}
+	heads := tree.Heads()
+	if len(heads) != 2 || tree.Revs[heads[0]].EditID != a2 || tree.Revs[heads[1]].EditID != b1 {
+		t.Fatalf("unexpected heads: %v", heads)
+	}
+	var history []tag.ID
+	for _, idx := range tree.History(tree.Winner()) {
+		history = append(history, tree.Revs[idx].EditID)
+	}
+	if fmt.Sprint(history) != fmt.Sprint([]tag.ID{a2, a1, root}) {
+		t.Fatalf("unexpected history: %v", history)
+	}
+
+	// Both replicas converge to the same state regardless of merge order
+	var converged [2][]byte
+	for i, pair := range [][2]*TxMsg{{replicaA, replicaB}, {replicaB, replicaA}} {
+		merged, err := MergeReplicas(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("MergeReplicas failed: %v", err)
+		}
+		if len(merged.Ops) != 4 {
+			t.Fatalf("expected 4 merged revisions, got %d", len(merged.Ops))
+		}
+		if err = merged.Squash(); err != nil {
+			t.Fatalf("Squash failed: %v", err)
+		}
+		val := Tag{}
+		if err = merged.Load(cellID, attrID, tag.ID{}, &val); err != nil || val.Text != "a2" {
+			t.Fatalf("expected a2, got %q (%v)", val.Text, err)
+		}
+		merged.SetGenesisID(tag.ID{1})
+		merged.MarshalToBuffer(&converged[i])
+	}
+	if !bytes.Equal(converged[0], converged[1]) {
+		t.Fatalf("replicas did not converge")
+	}
+}
+
 func TestRegistry(t *testing.T) {
 	reg := NewRegistry()
 	spec := reg.RegisterPrototype(AttrSpec.With("av"), &Tag{}, "")
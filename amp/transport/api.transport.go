@@ -0,0 +1,239 @@
+// Package transport offers amp.Transport implementations, allowing an amp.Host to connect to clients over a given data transport layer.
+//
+// All implementations follow the same TxMsg ownership rules:
+//   - SendTx() takes ownership of the given tx (the caller must call tx.AddRef() beforehand if it retains tx).
+//   - RecvTx() returns a tx owned by the caller, who calls tx.ReleaseRef() when done with it.
+//   - Once closed, SendTx() and RecvTx() return amp.ErrStreamClosed.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+// PipeOpts specifies how an in-memory pipe is created.
+type PipeOpts struct {
+	Label   string // prefix used for each end's amp.Transport.Label()
+	BufSize int    // number of txs buffered in each direction -- if 0, a default is used
+
+	// If set, every tx is serialized via TxMsg.MarshalToWriter() and read back via amp.ReadTxMsg(),
+	// exercising the wire format exactly as a network transport would.
+	Serialize bool
+
+	// In Serialize mode, the codec used to compress each tx's DataStore (see amp.TxCompression).
+	DataCodec amp.TxDataCodec
+}
+
+// DefaultPipeOpts returns the suggested options for NewPipe().
+func DefaultPipeOpts() PipeOpts {
+	return PipeOpts{
+		Label:     "pipe",
+		BufSize:   32,
+		DataCodec: amp.TxDataCodec_Deflate,
+	}
+}
+
+// NewPipe creates a bidirectional in-memory amp.Transport pair.
+// A tx sent on one end is received on the other, and closing either end closes the pipe.
+//
+// Typically, the host end is passed to amp.Host.StartNewSession() while a client (or test) drives the client end.
+func NewPipe(opts PipeOpts) (client, host amp.Transport) {
+	return newPipe(opts)
+}
+
+// StreamOpts specifies how a Stream is created.
+type StreamOpts struct {
+	Label   string // if empty, the conn's network and remote address are used
+	BufSize int    // number of txs buffered in each direction -- if 0, a default is used
+
+	// Limits enforced on each inbound tx -- if 0, amp.DefaultMaxTxBodyLen and amp.DefaultMaxTxDataLen are used (see amp.TxReader).
+	MaxTxBodyLen int
+	MaxTxDataLen int
+
+	// Codec used to compress outbound DataStores once the remote end advertises it (see amp.TxCompression).
+	// Compression is negotiated per connection, so TxDataCodec_Raw only disables compression for txs sent by this end.
+	DataCodec amp.TxDataCodec
+
+	// If set, Dial() and DialWebSocket() connect using TLS (see NewClientTLSConfig).
+	// Ignored for streams accepted by a service, which use the service's TLS option.
+	TLS *tls.Config
+}
+
+// DefaultStreamOpts returns the suggested options for a Stream.
+func DefaultStreamOpts() StreamOpts {
+	return StreamOpts{
+		BufSize:   32,
+		DataCodec: amp.TxDataCodec_Deflate,
+	}
+}
+
+// Stream is an amp.Transport that carries TxMsgs framed via TxMsg.MarshalToWriter() / amp.ReadTxMsg() over a net.Conn.
+//
+// Stream implements amp.AuthenticatedTransport: for a stream accepted over TLS where the client presented a certificate,
+// DeviceUID() returns the device ID derived from the certificate's public key (see utils.DeviceIDFromX509Pubkey).
+type Stream interface {
+	amp.AuthenticatedTransport
+
+	// StartPumps starts this Stream's read and write goroutines as children of the given Context,
+	// typically the amp.Session this Stream is bound to.
+	//
+	// The Stream closes when the given Context closes.  When the Stream closes, queued outbound txs are flushed before the conn is closed.
+	StartPumps(parent task.Context) error
+}
+
+// NewStream wraps a connected net.Conn (e.g. TCP or Unix domain socket) as a Stream.
+func NewStream(conn net.Conn, opts StreamOpts) Stream {
+	return newStream(conn, opts)
+}
+
+// Dial connects to an amp.Host service at the given address, where network is "tcp" or "unix".
+// If opts.TLS is set, the connection is made using TLS.
+// The caller is expected to call StartPumps() on the returned Stream.
+func Dial(network, addr string, opts StreamOpts) (Stream, error) {
+	var conn net.Conn
+	var err error
+	if opts.TLS != nil {
+		conn, err = tls.Dial(network, addr, opts.TLS)
+	} else {
+		conn, err = net.Dial(network, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newStream(conn, opts), nil
+}
+
+// NewServiceTLSConfig returns a TLS config for a NetService or WebSocketService that presents the given certificate.
+// If cert == nil, a self-signed certificate is generated via utils.MakeSelfSignedX509Certificate().
+//
+// clientAuth specifies if client certificates are requested (tls.RequestClientCert) or required (tls.RequireAnyClientCert).
+// Client certificates are typically self-signed, so they serve as a device identity rather than being verified against a CA.
+func NewServiceTLSConfig(cert *tls.Certificate, clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	return newServiceTLSConfig(cert, clientAuth)
+}
+
+// NewClientTLSConfig returns a TLS config for Dial() or DialWebSocket() that presents the given device certificate.
+// If cert == nil, a self-signed certificate is generated via utils.MakeSelfSignedX509Certificate(),
+// giving the client a device identity for the life of the returned config.
+//
+// The host's certificate is verified against rootCAs or, if nil, the system roots.  For a host having a self-signed
+// certificate, add it to rootCAs.
+func NewClientTLSConfig(cert *tls.Certificate, rootCAs *x509.CertPool) (*tls.Config, error) {
+	return newClientTLSConfig(cert, rootCAs, false)
+}
+
+// NewInsecureClientTLSConfig is NewClientTLSConfig except that the host's certificate is not verified, leaving the
+// connection open to a man-in-the-middle.  Only use this where the host's identity is established by other means.
+func NewInsecureClientTLSConfig(cert *tls.Certificate) (*tls.Config, error) {
+	return newClientTLSConfig(cert, nil, true)
+}
+
+// DeviceUIDFromTLS returns the device ID derived from the public key of the peer's leaf certificate,
+// or "" if no (supported) certificate was presented.
+func DeviceUIDFromTLS(state *tls.ConnectionState) string {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	return utils.DeviceIDFromX509Pubkey(state.PeerCertificates[0].PublicKey)
+}
+
+// NetServiceOpts specifies how a NetService listens for clients.
+type NetServiceOpts struct {
+	Label   string     // logging label for the service's task.Context
+	Network string     // "tcp" or "unix"
+	Addr    string     // listen address -- e.g. ":5192" or "/tmp/amp.sock"
+	Stream  StreamOpts // options for each accepted Stream
+
+	// If set, accepted connections use TLS (see NewServiceTLSConfig).
+	// When a client presents a certificate, its derived device ID becomes the session's Login.DeviceUID.
+	TLS *tls.Config
+}
+
+// DefaultNetServiceOpts returns options that listen for TCP clients on amp.Const_DefaultServicePort.
+func DefaultNetServiceOpts() NetServiceOpts {
+	return NetServiceOpts{
+		Network: "tcp",
+		Addr:    fmt.Sprintf(":%d", amp.Const_DefaultServicePort),
+		Stream:  DefaultStreamOpts(),
+	}
+}
+
+// NetService is an amp.HostService that calls amp.Host.StartNewSession() for each accepted client connection.
+type NetService interface {
+	amp.HostService
+
+	// Returns the address this service is listening on (or nil if not started).
+	Addr() net.Addr
+}
+
+// NewNetService creates a NetService to be started via amp.HostService.StartService().
+func NewNetService(opts NetServiceOpts) NetService {
+	return &netService{
+		opts: opts,
+	}
+}
+
+// WebSocketServiceOpts specifies how a WebSocketService serves clients.
+type WebSocketServiceOpts struct {
+	Label   string     // logging label for the service's task.Context
+	Addr    string     // HTTP listen address -- if empty, the service does not listen and is instead mounted as an http.Handler
+	Path    string     // URL path that upgrades to a WebSocket (when listening on Addr)
+	DevMode bool       // if set, any origin is allowed (see utils.UnrestrictedCors)
+	Stream  StreamOpts // options for each accepted Stream
+
+	// If set, the service listens on Addr using TLS (see NewServiceTLSConfig).
+	// When a client presents a certificate, its derived device ID becomes the session's Login.DeviceUID.
+	// When mounted as an http.Handler, client certificates are instead taken from the hosting http.Server's TLS state.
+	TLS *tls.Config
+}
+
+// DefaultWebSocketServiceOpts returns the suggested options for a WebSocketService.
+func DefaultWebSocketServiceOpts() WebSocketServiceOpts {
+	return WebSocketServiceOpts{
+		Addr:   fmt.Sprintf(":%d", amp.Const_DefaultServicePort+1),
+		Path:   "/amp",
+		Stream: DefaultStreamOpts(),
+	}
+}
+
+// WebSocketService is an amp.HostService that upgrades HTTP requests to WebSockets, starting a new session for each.
+// Each TxMsg is carried as a single binary WebSocket message.
+type WebSocketService interface {
+	NetService
+
+	// Upgrades the request to a WebSocket and starts a new session, allowing this service to be mounted on an existing http.ServeMux.
+	// Responds with 503 (service unavailable) until StartService() is called.
+	http.Handler
+}
+
+// NewWebSocketService creates a WebSocketService to be started via amp.HostService.StartService().
+func NewWebSocketService(opts WebSocketServiceOpts) WebSocketService {
+	return newWebSocketService(opts)
+}
+
+// NewWebSocket wraps a connected WebSocket as a Stream.
+func NewWebSocket(conn *websocket.Conn, opts StreamOpts) Stream {
+	return newWebSocket(conn, opts)
+}
+
+// DialWebSocket connects to a WebSocketService at the given URL (e.g. "ws://localhost:5193/amp").
+// For a "wss://" URL, opts.TLS is used as the TLS config (if set).
+// The caller is expected to call StartPumps() on the returned Stream.
+func DialWebSocket(url string, opts StreamOpts) (Stream, error) {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = opts.TLS
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newWebSocket(conn, opts), nil
+}
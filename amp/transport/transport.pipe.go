@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+)
+
+// pipeMsg is a tx in flight -- buf is used instead of tx when in PipeOpts.Serialize mode.
+type pipeMsg struct {
+	tx  *amp.TxMsg
+	buf []byte
+}
+
+// pipeShared is the state shared by both ends of a pipe.
+type pipeShared struct {
+	closing   chan struct{}
+	closeOnce sync.Once
+	sending   sync.RWMutex // held by senders so that Close() can release every tx left in an inbox
+	serialize bool
+}
+
+// pipeEnd implements amp.Transport
+type pipeEnd struct {
+	*pipeShared
+	label  string
+	inbox  <-chan pipeMsg
+	outbox chan<- pipeMsg
+	comp   amp.TxCompression // used for serialization
+	scrap  []byte            // used for serialization
+	sendMu sync.Mutex
+}
+
+func newPipe(opts PipeOpts) (client, host *pipeEnd) {
+	if opts.BufSize <= 0 {
+		opts.BufSize = DefaultPipeOpts().BufSize
+	}
+	if opts.Label == "" {
+		opts.Label = DefaultPipeOpts().Label
+	}
+
+	shared := &pipeShared{
+		closing:   make(chan struct{}),
+		serialize: opts.Serialize,
+	}
+	toHost := make(chan pipeMsg, opts.BufSize)
+	toClient := make(chan pipeMsg, opts.BufSize)
+
+	client = &pipeEnd{
+		pipeShared: shared,
+		label:      opts.Label + " (client)",
+		inbox:      toClient,
+		outbox:     toHost,
+	}
+	host = &pipeEnd{
+		pipeShared: shared,
+		label:      opts.Label + " (host)",
+		inbox:      toHost,
+		outbox:     toClient,
+	}
+	client.comp.Codec = opts.DataCodec
+	host.comp.Codec = opts.DataCodec
+	return client, host
+}
+
+// Implements amp.Transport
+func (p *pipeEnd) Label() string {
+	return p.label
+}
+
+// Implements amp.Transport
+//
+// Txs sent to this end and not yet received are released, whereas the other end still receives txs sent to it.
+func (p *pipeEnd) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+
+	// Once in-flight sends have returned, no more txs can arrive
+	p.sending.Lock()
+	defer p.sending.Unlock()
+	for {
+		select {
+		case msg := <-p.inbox:
+			if msg.tx != nil {
+				msg.tx.ReleaseRef()
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// Implements amp.Transport
+func (p *pipeEnd) SendTx(tx *amp.TxMsg) error {
+	p.sending.RLock()
+	defer p.sending.RUnlock()
+
+	select {
+	case <-p.closing:
+		tx.ReleaseRef()
+		return amp.ErrStreamClosed
+	default:
+	}
+
+	msg := pipeMsg{
+		tx: tx,
+	}
+	if p.serialize {
+		p.sendMu.Lock()
+		buf := bytes.Buffer{}
+		err := p.comp.MarshalToWriter(tx, &p.scrap, &buf)
+		p.sendMu.Unlock()
+
+		tx.ReleaseRef()
+		if err != nil {
+			return err
+		}
+		msg.tx = nil
+		msg.buf = buf.Bytes()
+	}
+
+	select {
+	case p.outbox <- msg:
+		return nil
+	case <-p.closing:
+		if msg.tx != nil {
+			msg.tx.ReleaseRef()
+		}
+		return amp.ErrStreamClosed
+	}
+}
+
+// Implements amp.Transport
+//
+// Txs sent before the pipe was closed are still received (as with a network stream closed gracefully).
+func (p *pipeEnd) RecvTx() (*amp.TxMsg, error) {
+	var msg pipeMsg
+
+	select {
+	case msg = <-p.inbox:
+	default:
+		select {
+		case msg = <-p.inbox:
+		case <-p.closing:
+			select {
+			case msg = <-p.inbox:
+			default:
+				return nil, amp.ErrStreamClosed
+			}
+		}
+	}
+
+	if msg.buf != nil {
+		return p.comp.ReadTxMsg(bytes.NewReader(msg.buf))
+	}
+	return msg.tx, nil
+}
@@ -0,0 +1,197 @@
+package transport
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+)
+
+// netService implements NetService
+type netService struct {
+	task.Context
+	opts     NetServiceOpts
+	host     amp.Host
+	listener net.Listener
+	sessions sessionGroup
+}
+
+// Implements NetService
+func (svc *netService) Addr() net.Addr {
+	if svc.listener == nil {
+		return nil
+	}
+	return svc.listener.Addr()
+}
+
+// Implements amp.HostService
+func (svc *netService) StartService(on amp.Host) error {
+	if svc.host != nil {
+		return task.ErrAlreadyStarted
+	}
+	if svc.opts.Network == "" {
+		svc.opts.Network = DefaultNetServiceOpts().Network
+	}
+	if svc.opts.Addr == "" {
+		svc.opts.Addr = DefaultNetServiceOpts().Addr
+	}
+	if svc.opts.Label == "" {
+		svc.opts.Label = svc.opts.Network + " service"
+	}
+
+	// A stale socket file from a previous run prevents listening, but any other file is left as is
+	if svc.opts.Network == "unix" {
+		if info, err := os.Lstat(svc.opts.Addr); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err = os.Remove(svc.opts.Addr); err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	svc.listener, err = net.Listen(svc.opts.Network, svc.opts.Addr)
+	if err != nil {
+		return err
+	}
+
+	svc.Context, err = on.StartChild(&task.Task{
+		Info: task.Info{
+			Label: svc.opts.Label + " @ " + svc.listener.Addr().String(),
+		},
+		OnClosing: func() {
+			svc.sessions.stop()
+			svc.listener.Close()
+		},
+	})
+	if err != nil {
+		svc.listener.Close()
+		return err
+	}
+
+	// svc.host is only set once started so that a failed start can be retried
+	svc.host = on
+	if _, err = svc.Go("accept", svc.acceptConns); err != nil {
+		svc.Close()
+		svc.host = nil
+		return err
+	}
+	return nil
+}
+
+// Implements amp.HostService
+//
+// Stops accepting new connections and blocks until all sessions started by this service have closed.
+func (svc *netService) GracefulStop() {
+	if svc.host == nil {
+		return
+	}
+	svc.sessions.stop()
+	svc.listener.Close()
+	svc.sessions.wait()
+}
+
+func (svc *netService) acceptConns(ctx task.Context) {
+	for {
+		conn, err := svc.listener.Accept()
+		if err != nil {
+			if !svc.sessions.isStopping() && !errors.Is(err, net.ErrClosed) {
+				ctx.Log().Warnf("accept error: %v", err)
+			}
+			return
+		}
+		if svc.opts.TLS == nil {
+			svc.serveConn(conn)
+			continue
+		}
+
+		// Complete the TLS handshake off the accept loop so a slow client can't stall other clients.
+		if !svc.sessions.add() {
+			conn.Close()
+			return
+		}
+		go func() {
+			defer svc.sessions.done()
+			tlsConn, err := serverHandshake(ctx, conn, svc.opts.TLS)
+			if err != nil {
+				ctx.Log().Warnf("TLS handshake with %v failed: %v", conn.RemoteAddr(), err)
+				return
+			}
+			svc.serveConn(tlsConn)
+		}()
+	}
+}
+
+func (svc *netService) serveConn(conn net.Conn) {
+	startSession(svc.host, svc, newStream(conn, svc.opts.Stream), &svc.sessions)
+}
+
+// startSession starts a new session on the given Host for a Stream accepted by the given HostService.
+// The session is tracked by the given sessionGroup until it is done, and the stream is closed if the service is stopping.
+func startSession(on amp.Host, svc amp.HostService, st *stream, sessions *sessionGroup) {
+	if !sessions.add() {
+		st.Close()
+		st.conn.Close()
+		return
+	}
+	sess, err := on.StartNewSession(svc, st)
+	if err != nil {
+		svc.Log().Warnf("failed to start session for %v: %v", st.Label(), err)
+		st.Close()
+		sessions.done()
+		return
+	}
+	if err = st.StartPumps(sess); err != nil {
+		sess.Close()
+	}
+
+	go func() {
+		<-sess.Done()
+		sessions.done()
+	}()
+}
+
+// sessionGroup tracks the sessions started by a service so that GracefulStop() can wait for them to close.
+//
+// A session is only added while the service is not stopping, checked under the same lock that stop() sets stopping,
+// so no session is added once wait() has been called.
+type sessionGroup struct {
+	mu       sync.Mutex
+	stopping bool
+	active   sync.WaitGroup
+}
+
+// add adds a session unless stopping, returning false if stopping.  If added, done() must be called when it closes.
+func (g *sessionGroup) add() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopping {
+		return false
+	}
+	g.active.Add(1)
+	return true
+}
+
+func (g *sessionGroup) done() {
+	g.active.Done()
+}
+
+func (g *sessionGroup) stop() {
+	g.mu.Lock()
+	g.stopping = true
+	g.mu.Unlock()
+}
+
+func (g *sessionGroup) isStopping() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stopping
+}
+
+// wait blocks until all sessions have closed and is called after stop().
+func (g *sessionGroup) wait() {
+	g.active.Wait()
+}
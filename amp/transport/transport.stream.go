@@ -0,0 +1,269 @@
+package transport
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+)
+
+// How long queued outbound txs are given to flush once a stream is closing.
+const streamFlushTimeout = 2 * time.Second
+
+// txConn frames txs over an underlying connection.
+type txConn interface {
+	ReadTx() (*amp.TxMsg, error)
+	WriteTx(tx *amp.TxMsg) error // may buffer until Flush()
+	Flush() error
+	SetWriteDeadline(t time.Time) error
+	Close() error
+}
+
+// stream implements Stream
+type stream struct {
+	label     string
+	deviceUID string // see DeviceUID()
+	conn      txConn
+	inbox     chan *amp.TxMsg
+	outbox    chan *amp.TxMsg
+	closing   chan struct{}
+	closeOnce sync.Once
+	pumping   atomic.Bool
+}
+
+func newStream(conn net.Conn, opts StreamOpts) *stream {
+	if opts.Label == "" {
+		opts.Label = conn.RemoteAddr().Network() + "://" + conn.RemoteAddr().String()
+	}
+	nc := &netConn{
+		conn: conn,
+		r:    bufio.NewReaderSize(conn, 32*1024),
+		w:    bufio.NewWriterSize(conn, 32*1024),
+	}
+	nc.comp.Codec = opts.DataCodec
+	nc.rd = newTxReader(&nc.comp, opts)
+	st := newStreamWith(nc, opts)
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		state := tlsConn.ConnectionState()
+		st.deviceUID = DeviceUIDFromTLS(&state)
+	}
+	return st
+}
+
+func newStreamWith(conn txConn, opts StreamOpts) *stream {
+	if opts.BufSize <= 0 {
+		opts.BufSize = DefaultStreamOpts().BufSize
+	}
+	return &stream{
+		label:   opts.Label,
+		conn:    conn,
+		inbox:   make(chan *amp.TxMsg, opts.BufSize),
+		outbox:  make(chan *amp.TxMsg, opts.BufSize),
+		closing: make(chan struct{}),
+	}
+}
+
+// Implements amp.Transport
+func (st *stream) Label() string {
+	return st.label
+}
+
+// Implements amp.AuthenticatedTransport
+func (st *stream) DeviceUID() string {
+	return st.deviceUID
+}
+
+// Implements amp.Transport
+func (st *stream) Close() error {
+	st.closeOnce.Do(func() {
+		close(st.closing)
+
+		// If pumping, the write pump closes the conn once queued txs are flushed.
+		if !st.pumping.Load() {
+			st.conn.Close()
+		}
+	})
+	return nil
+}
+
+// Implements amp.Transport
+func (st *stream) SendTx(tx *amp.TxMsg) error {
+	select {
+	case <-st.closing:
+		tx.ReleaseRef()
+		return amp.ErrStreamClosed
+	default:
+	}
+
+	select {
+	case st.outbox <- tx:
+		return nil
+	case <-st.closing:
+		tx.ReleaseRef()
+		return amp.ErrStreamClosed
+	}
+}
+
+// Implements amp.Transport
+func (st *stream) RecvTx() (*amp.TxMsg, error) {
+	select {
+	case tx := <-st.inbox:
+		return tx, nil
+	default:
+		select {
+		case tx := <-st.inbox:
+			return tx, nil
+		case <-st.closing:
+			select {
+			case tx := <-st.inbox:
+				return tx, nil
+			default:
+				return nil, amp.ErrStreamClosed
+			}
+		}
+	}
+}
+
+// Implements Stream
+func (st *stream) StartPumps(parent task.Context) error {
+	if !st.pumping.CompareAndSwap(false, true) {
+		return task.ErrAlreadyStarted
+	}
+
+	select {
+	case <-st.closing:
+		st.conn.Close()
+		return amp.ErrStreamClosed
+	default:
+	}
+
+	_, err := parent.Go("txWriter: "+st.label, st.writePump)
+	if err == nil {
+		_, err = parent.Go("txReader: "+st.label, st.readPump)
+	}
+	if err != nil {
+		st.Close()
+		st.conn.Close()
+	}
+	return err
+}
+
+// readPump reads framed txs from the conn until it closes.
+func (st *stream) readPump(ctx task.Context) {
+	for {
+		tx, err := st.conn.ReadTx()
+		if err != nil {
+			select {
+			case <-st.closing:
+			default:
+				if !isClosedErr(err) {
+					ctx.Log().Warnf("ReadTxMsg error: %v", err)
+				}
+			}
+			break
+		}
+
+		select {
+		case st.inbox <- tx:
+		case <-st.closing:
+			tx.ReleaseRef()
+		}
+	}
+
+	st.Close()
+}
+
+// writePump writes queued txs to the conn until this stream or the parent Context closes.
+func (st *stream) writePump(ctx task.Context) {
+	var err error
+
+	writeTx := func(tx *amp.TxMsg) {
+		if err == nil {
+			err = st.conn.WriteTx(tx)
+		}
+		tx.ReleaseRef()
+	}
+
+	for running := true; running && err == nil; {
+		select {
+		case tx := <-st.outbox:
+			writeTx(tx)
+
+			// Only flush once there is nothing more to send
+			if len(st.outbox) == 0 && err == nil {
+				err = st.conn.Flush()
+			}
+		case <-st.closing:
+			running = false
+		case <-ctx.Closing():
+			running = false
+		}
+	}
+
+	if err != nil {
+		ctx.Log().Warnf("write error: %v", err)
+	}
+
+	st.Close()
+
+	// Flush what remains as a courtesy to the remote end
+	st.conn.SetWriteDeadline(time.Now().Add(streamFlushTimeout))
+	for len(st.outbox) > 0 {
+		writeTx(<-st.outbox)
+	}
+	if err == nil {
+		st.conn.Flush()
+	}
+	st.conn.Close()
+}
+
+// newTxReader returns a TxReader enforcing the given StreamOpts limits, notifying comp of the codecs the peer accepts.
+func newTxReader(comp *amp.TxCompression, opts StreamOpts) amp.TxReader {
+	return amp.TxReader{
+		MaxBodyLen:  opts.MaxTxBodyLen,
+		MaxDataLen:  opts.MaxTxDataLen,
+		Compression: comp,
+	}
+}
+
+// isClosedErr returns true if the given error denotes a connection that was closed normally.
+func isClosedErr(err error) bool {
+	return err == io.EOF || errors.Is(err, net.ErrClosed) || err == amp.ErrStreamClosed
+}
+
+// netConn implements txConn over a net.Conn
+type netConn struct {
+	conn  net.Conn
+	r     *bufio.Reader
+	w     *bufio.Writer
+	rd    amp.TxReader
+	comp  amp.TxCompression
+	scrap []byte
+}
+
+func (c *netConn) ReadTx() (*amp.TxMsg, error) {
+	return c.rd.ReadTxMsg(c.r)
+}
+
+func (c *netConn) WriteTx(tx *amp.TxMsg) error {
+	return c.comp.MarshalToWriter(tx, &c.scrap, c.w)
+}
+
+func (c *netConn) Flush() error {
+	return c.w.Flush()
+}
+
+func (c *netConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+func (c *netConn) Close() error {
+	return c.conn.Close()
+}
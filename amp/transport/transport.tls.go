@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+// How long an accepted connection has to complete its TLS handshake.
+const tlsHandshakeTimeout = 10 * time.Second
+
+func newServiceTLSConfig(cert *tls.Certificate, clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	if cert == nil {
+		var err error
+		if cert, err = utils.MakeSelfSignedX509Certificate(); err != nil {
+			return nil, err
+		}
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{*cert},
+		ClientAuth:   clientAuth,
+	}, nil
+}
+
+func newClientTLSConfig(cert *tls.Certificate, rootCAs *x509.CertPool, insecureSkipVerify bool) (*tls.Config, error) {
+	if cert == nil {
+		var err error
+		if cert, err = utils.MakeSelfSignedX509Certificate(); err != nil {
+			return nil, err
+		}
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS13,
+		Certificates:       []tls.Certificate{*cert},
+		RootCAs:            rootCAs,
+		InsecureSkipVerify: insecureSkipVerify,
+	}, nil
+}
+
+// serverHandshake wraps an accepted conn using the given TLS config and completes the TLS handshake.
+// The handshake is cancelled if ctx is done or it does not complete within tlsHandshakeTimeout.
+func serverHandshake(ctx context.Context, conn net.Conn, config *tls.Config) (*tls.Conn, error) {
+	tlsConn := tls.Server(conn, config)
+
+	ctx, cancel := context.WithTimeout(ctx, tlsHandshakeTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
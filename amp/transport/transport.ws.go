@@ -0,0 +1,214 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+func newWebSocket(conn *websocket.Conn, opts StreamOpts) *stream {
+	if opts.Label == "" {
+		scheme := "ws://"
+		if _, isTLS := conn.NetConn().(*tls.Conn); isTLS {
+			scheme = "wss://"
+		}
+		opts.Label = scheme + conn.RemoteAddr().String()
+	}
+	wc := &wsConn{
+		conn: conn,
+	}
+	wc.comp.Codec = opts.DataCodec
+	wc.rd = newTxReader(&wc.comp, opts)
+	return newStreamWith(wc, opts)
+}
+
+// wsConn implements txConn, carrying each tx as a single binary WebSocket message.
+type wsConn struct {
+	conn  *websocket.Conn
+	rd    amp.TxReader
+	comp  amp.TxCompression
+	scrap []byte
+}
+
+func (c *wsConn) ReadTx() (*amp.TxMsg, error) {
+	for {
+		msgType, r, err := c.conn.NextReader()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				err = amp.ErrStreamClosed
+			}
+			return nil, err
+		}
+
+		// Text messages are not part of the protocol and are ignored
+		if msgType != websocket.BinaryMessage {
+			io.Copy(io.Discard, r)
+			continue
+		}
+		return c.rd.ReadTxMsg(r)
+	}
+}
+
+func (c *wsConn) WriteTx(tx *amp.TxMsg) error {
+	c.comp.MarshalToBuffer(tx, &c.scrap)
+	return c.conn.WriteMessage(websocket.BinaryMessage, c.scrap)
+}
+
+func (c *wsConn) Flush() error {
+	return nil
+}
+
+func (c *wsConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+func (c *wsConn) Close() error {
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	c.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+	return c.conn.Close()
+}
+
+// wsService implements WebSocketService
+type wsService struct {
+	task.Context
+	opts     WebSocketServiceOpts
+	host     amp.Host
+	started  atomic.Bool
+	handler  http.Handler
+	upgrader websocket.Upgrader
+	listener net.Listener
+	server   *http.Server
+	sessions sessionGroup
+}
+
+func newWebSocketService(opts WebSocketServiceOpts) *wsService {
+	if opts.Label == "" {
+		opts.Label = "ws service"
+	}
+	if opts.Path == "" {
+		opts.Path = DefaultWebSocketServiceOpts().Path
+	}
+
+	svc := &wsService{
+		opts: opts,
+	}
+	svc.handler = http.HandlerFunc(svc.serveUpgrade)
+	if opts.DevMode {
+		svc.upgrader.CheckOrigin = func(r *http.Request) bool { return true }
+		svc.handler = utils.UnrestrictedCors(svc.handler)
+	}
+	return svc
+}
+
+// Implements NetService
+func (svc *wsService) Addr() net.Addr {
+	if svc.listener == nil {
+		return nil
+	}
+	return svc.listener.Addr()
+}
+
+// Implements http.Handler
+func (svc *wsService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	svc.handler.ServeHTTP(w, r)
+}
+
+func (svc *wsService) serveUpgrade(w http.ResponseWriter, r *http.Request) {
+	if !svc.started.Load() || svc.sessions.isStopping() {
+		http.Error(w, "amp host service not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := svc.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade() has already responded
+	}
+	st := newWebSocket(conn, svc.opts.Stream)
+	st.deviceUID = DeviceUIDFromTLS(r.TLS)
+	startSession(svc.host, svc, st, &svc.sessions)
+}
+
+// Implements amp.HostService
+func (svc *wsService) StartService(on amp.Host) error {
+	if svc.host != nil {
+		return task.ErrAlreadyStarted
+	}
+
+	label := svc.opts.Label
+	if svc.opts.Addr != "" {
+		var err error
+		svc.listener, err = net.Listen("tcp", svc.opts.Addr)
+		if err != nil {
+			return err
+		}
+		label += " @ " + svc.listener.Addr().String()
+		if svc.opts.TLS != nil {
+			svc.listener = tls.NewListener(svc.listener, svc.opts.TLS)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(svc.opts.Path, svc)
+		svc.server = &http.Server{
+			Handler: mux,
+		}
+	}
+
+	var err error
+	svc.Context, err = on.StartChild(&task.Task{
+		Info: task.Info{
+			Label: label,
+		},
+		OnClosing: func() {
+			svc.sessions.stop()
+			if svc.server != nil {
+				svc.server.Close()
+			}
+		},
+	})
+	if err == nil && svc.server != nil {
+		_, err = svc.Go("http", func(ctx task.Context) {
+			err := svc.server.Serve(svc.listener)
+			if err != nil && err != http.ErrServerClosed {
+				ctx.Log().Warnf("http server error: %v", err)
+			}
+		})
+		if err != nil {
+			svc.Close()
+		}
+	}
+	if err != nil {
+		if svc.listener != nil {
+			svc.listener.Close()
+		}
+		return err
+	}
+
+	// svc.host is only set once started so that a failed start can be retried
+	svc.host = on
+	svc.started.Store(true)
+	return nil
+}
+
+// Implements amp.HostService
+//
+// Stops accepting new connections and blocks until all sessions started by this service have closed.
+func (svc *wsService) GracefulStop() {
+	if !svc.started.Load() {
+		return
+	}
+	svc.sessions.stop()
+	if svc.server != nil {
+		svc.server.Shutdown(context.Background())
+	}
+	svc.sessions.wait()
+}
@@ -0,0 +1,416 @@
+package transport_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/host"
+	"github.com/amp-3d/amp-sdk-go/amp/internal/testhost"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/amp/transport"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+	"github.com/amp-3d/amp-sdk-go/stdlib/task"
+	"github.com/amp-3d/amp-sdk-go/stdlib/utils"
+)
+
+func makeTestTx(t *testing.T, numOps int) *amp.TxMsg {
+	tx := amp.NewTxMsg(true)
+	tx.Status = amp.OpStatus_Syncing
+	tx.SetContextID(tag.Now())
+	for i := 0; i < numOps; i++ {
+		err := tx.Upsert(tag.ID{0, 1, 2}, amp.AttrSpec.With("Tag").ID, tag.ID{0, 0, uint64(i)}, &amp.Tag{
+			Text: "tx op",
+			URL:  "amp://test/",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tx
+}
+
+func checkTxEqual(t *testing.T, tx1, tx2 *amp.TxMsg) {
+	t.Helper()
+
+	if tx1.TxInfo != tx2.TxInfo {
+		t.Fatalf("TxInfo mismatch")
+	}
+	if len(tx1.Ops) != len(tx2.Ops) {
+		t.Fatalf("Ops mismatch")
+	}
+	for i := range tx1.Ops {
+		op1, op2 := tx1.Ops[i], tx2.Ops[i]
+		op1.EditID[2], op2.EditID[2] = 0, 0 // EditID is truncated to 16 bytes on the wire
+		if op1 != op2 {
+			t.Fatalf("TxOp %d mismatch", i)
+		}
+	}
+	if !bytes.Equal(tx1.DataStore, tx2.DataStore) {
+		t.Fatalf("DataStore mismatch")
+	}
+}
+
+func TestPipe(t *testing.T) {
+	for _, serialize := range []bool{false, true} {
+		opts := transport.DefaultPipeOpts()
+		opts.Serialize = serialize
+		client, host := transport.NewPipe(opts)
+
+		tx := makeTestTx(t, 33)
+		tx.AddRef() // retain tx so we can compare it after handing it off
+		if err := client.SendTx(tx); err != nil {
+			t.Fatal(err)
+		}
+		recv, err := host.RecvTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTxEqual(t, tx, recv)
+		if serialize == (recv == tx) {
+			t.Errorf("unexpected tx handoff (serialize=%v)", serialize)
+		}
+		recv.ReleaseRef()
+		tx.ReleaseRef()
+
+		// Having read a tx from the client, the host end compresses large DataStores in serialize mode
+		tx = makeTestTx(t, 500)
+		tx.AddRef()
+		if err = host.SendTx(tx); err != nil {
+			t.Fatal(err)
+		}
+		if recv, err = client.RecvTx(); err != nil {
+			t.Fatal(err)
+		}
+		checkTxEqual(t, tx, recv)
+		recv.ReleaseRef()
+		tx.ReleaseRef()
+
+		// txs sent before close are still received
+		host.SendTx(makeTestTx(t, 1))
+		host.Close()
+		if recv, err = client.RecvTx(); err != nil || len(recv.Ops) != 1 {
+			t.Fatalf("expected pending tx to be received, got %v", err)
+		}
+		if _, err = client.RecvTx(); err != amp.ErrStreamClosed {
+			t.Fatalf("expected ErrStreamClosed, got %v", err)
+		}
+		if err = client.SendTx(makeTestTx(t, 1)); err != amp.ErrStreamClosed {
+			t.Fatalf("expected ErrStreamClosed, got %v", err)
+		}
+	}
+
+	// txs never received are released once the receiving end is closed
+	client, host := transport.NewPipe(transport.DefaultPipeOpts())
+	tx := makeTestTx(t, 1)
+	tx.AddRef()
+	if err := client.SendTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+	host.Close()
+	tx.ReleaseRef()
+	if len(tx.Ops) != 0 {
+		t.Fatal("expected pending tx to be released on close")
+	}
+}
+
+// pinOverTransport pins a test cell over the given client transport and checks the response.
+func pinOverTransport(t *testing.T, client amp.Transport, wantLabel string) {
+	t.Helper()
+
+	pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{
+		PinTarget: &amp.Tag{
+			URL: "amp://host-test/transport",
+		},
+		StateSync: amp.StateSync_CloseOnSync,
+	})
+	reqID := pinTx.GenesisID()
+	if err := client.SendTx(pinTx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := testhost.RecvTx(t, client)
+	label := amp.Tag{}
+	if tx.Status != amp.OpStatus_Synced || tx.ContextID() != reqID {
+		t.Fatalf("expected synced state, got status %v", tx.Status)
+	}
+	if err := tx.LoadFirst(std.CellProperties.ID, &label); err != nil || label.Text != wantLabel {
+		t.Fatalf("unexpected cell label %q (%v)", label.Text, err)
+	}
+	tx.ReleaseRef()
+
+	tx = testhost.RecvTx(t, client)
+	if tx.Status != amp.OpStatus_Closed || tx.ContextID() != reqID {
+		t.Fatalf("expected request to be closed, got status %v", tx.Status)
+	}
+	tx.ReleaseRef()
+}
+
+func TestNetService(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+
+	clientCtx, _ := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "test client",
+		},
+	})
+	defer clientCtx.Close()
+
+	for _, network := range []string{"tcp", "unix"} {
+		opts := transport.DefaultNetServiceOpts()
+		opts.Network = network
+		if network == "tcp" {
+			opts.Addr = "127.0.0.1:0"
+		} else {
+			opts.Addr = filepath.Join(t.TempDir(), "amp.sock")
+		}
+
+		svc := transport.NewNetService(opts)
+		if err := svc.StartService(h); err != nil {
+			t.Fatalf("StartService(%s) failed: %v", network, err)
+		}
+
+		client, err := transport.Dial(network, svc.Addr().String(), transport.DefaultStreamOpts())
+		if err != nil {
+			t.Fatalf("Dial(%s) failed: %v", network, err)
+		}
+		if err = client.StartPumps(clientCtx); err != nil {
+			t.Fatal(err)
+		}
+
+		pinOverTransport(t, client, "hello /transport")
+
+		// Once the client disconnects, its session closes and GracefulStop() completes.
+		client.Close()
+		stopped := make(chan struct{})
+		go func() {
+			svc.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("GracefulStop(%s) timed out", network)
+		}
+		svc.Close()
+	}
+}
+
+func TestNetServiceNotSocket(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+
+	// A file at the socket path that is not a socket is not removed
+	opts := transport.DefaultNetServiceOpts()
+	opts.Network = "unix"
+	opts.Addr = filepath.Join(t.TempDir(), "amp.sock")
+	if err := os.WriteFile(opts.Addr, []byte("not a socket"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	svc := transport.NewNetService(opts)
+	if err := svc.StartService(h); err == nil {
+		svc.Close()
+		t.Fatalf("expected StartService to fail")
+	}
+	if data, err := os.ReadFile(opts.Addr); err != nil || string(data) != "not a socket" {
+		t.Fatalf("expected file to be left as is")
+	}
+
+	// Once the path is free, the same service can be started
+	if err := os.Remove(opts.Addr); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.StartService(h); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	svc.Close()
+}
+
+func TestWebSocketService(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+
+	clientCtx, _ := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "test client",
+		},
+	})
+	defer clientCtx.Close()
+
+	opts := transport.DefaultWebSocketServiceOpts()
+	opts.Addr = "127.0.0.1:0"
+	opts.DevMode = true
+	svc := transport.NewWebSocketService(opts)
+
+	// A service that fails to start can be started again
+	closed := testhost.StartHost(t, host.DefaultOpts())
+	closed.Close()
+	<-closed.Done()
+	if err := svc.StartService(closed); err == nil {
+		t.Fatal("expected StartService to fail on a closed host")
+	}
+	if err := svc.StartService(h); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer svc.Close()
+
+	client, err := transport.DialWebSocket("ws://"+svc.Addr().String()+opts.Path, transport.DefaultStreamOpts())
+	if err != nil {
+		t.Fatalf("DialWebSocket failed: %v", err)
+	}
+	if err = client.StartPumps(clientCtx); err != nil {
+		t.Fatal(err)
+	}
+
+	pinOverTransport(t, client, "hello /transport")
+	pinOverTransport(t, client, "hello /transport")
+
+	client.Close()
+	stopped := make(chan struct{})
+	go func() {
+		svc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GracefulStop timed out")
+	}
+}
+
+func TestTLS(t *testing.T) {
+	h := testhost.StartHost(t, host.DefaultOpts())
+
+	clientCtx, _ := task.Start(&task.Task{
+		Info: task.Info{
+			Label: "test client",
+		},
+	})
+	defer clientCtx.Close()
+
+	hostCert, err := utils.MakeSelfSignedX509Certificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostTLS, err := transport.NewServiceTLSConfig(hostCert, tls.RequestClientCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client verifies the host against the host's self-signed cert
+	leaf, err := x509.ParseCertificate(hostCert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(leaf)
+
+	deviceCert, err := utils.MakeSelfSignedX509Certificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deviceLeaf, _ := x509.ParseCertificate(deviceCert.Certificate[0])
+	deviceUID := utils.DeviceIDFromX509Pubkey(deviceLeaf.PublicKey)
+	if deviceUID == "" {
+		t.Fatal("expected a device ID")
+	}
+
+	dialTLS := func(t *testing.T, cert *tls.Certificate, dial func(opts transport.StreamOpts) (transport.Stream, error)) transport.Stream {
+		t.Helper()
+		var err error
+		opts := transport.DefaultStreamOpts()
+		if opts.TLS, err = transport.NewClientTLSConfig(cert, rootCAs); err != nil {
+			t.Fatal(err)
+		}
+		if cert == nil {
+			opts.TLS.Certificates = nil // connect anonymously
+		}
+		client, err := dial(opts)
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		if err = client.StartPumps(clientCtx); err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	t.Run("NetService", func(t *testing.T) {
+		opts := transport.DefaultNetServiceOpts()
+		opts.Addr = "127.0.0.1:0"
+		opts.TLS = hostTLS
+		svc := transport.NewNetService(opts)
+		if err := svc.StartService(h); err != nil {
+			t.Fatal(err)
+		}
+		defer svc.Close()
+
+		dial := func(opts transport.StreamOpts) (transport.Stream, error) {
+			return transport.Dial("tcp", svc.Addr().String(), opts)
+		}
+
+		// The session's DeviceUID is derived from the client cert
+		client := dialTLS(t, deviceCert, dial)
+		pinOverTransport(t, client, "hello /transport "+deviceUID)
+		client.Close()
+
+		// Client certs are optional
+		client = dialTLS(t, nil, dial)
+		pinOverTransport(t, client, "hello /transport")
+		client.Close()
+
+		// The host's self-signed cert is not trusted by default, unless verification is explicitly skipped
+		streamOpts := transport.DefaultStreamOpts()
+		if streamOpts.TLS, err = transport.NewClientTLSConfig(deviceCert, nil); err != nil {
+			t.Fatal(err)
+		}
+		if untrusted, err := transport.Dial("tcp", svc.Addr().String(), streamOpts); err == nil {
+			untrusted.Close()
+			t.Fatal("expected the host's self-signed cert to fail verification")
+		}
+		if streamOpts.TLS, err = transport.NewInsecureClientTLSConfig(deviceCert); err != nil {
+			t.Fatal(err)
+		}
+		client, err = transport.Dial("tcp", svc.Addr().String(), streamOpts)
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		if err = client.StartPumps(clientCtx); err != nil {
+			t.Fatal(err)
+		}
+		pinOverTransport(t, client, "hello /transport "+deviceUID)
+		client.Close()
+
+		// Plaintext clients fail the handshake
+		plain, err := transport.Dial("tcp", svc.Addr().String(), transport.DefaultStreamOpts())
+		if err == nil {
+			plain.StartPumps(clientCtx)
+			pinTx, _ := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.PinRequest{})
+			plain.SendTx(pinTx)
+			if _, err = plain.RecvTx(); err != amp.ErrStreamClosed {
+				t.Fatalf("expected plaintext client to be disconnected, got %v", err)
+			}
+		}
+	})
+
+	t.Run("WebSocketService", func(t *testing.T) {
+		opts := transport.DefaultWebSocketServiceOpts()
+		opts.Addr = "127.0.0.1:0"
+		opts.TLS = hostTLS
+		svc := transport.NewWebSocketService(opts)
+		if err := svc.StartService(h); err != nil {
+			t.Fatal(err)
+		}
+		defer svc.Close()
+
+		client := dialTLS(t, deviceCert, func(opts transport.StreamOpts) (transport.Stream, error) {
+			return transport.DialWebSocket("wss://"+svc.Addr().String()+"/amp", opts)
+		})
+		pinOverTransport(t, client, "hello /transport "+deviceUID)
+		client.Close()
+	})
+}
@@ -0,0 +1,201 @@
+// amp-attrgen generates the tag.Value methods (MarshalToStore, TagSpec, and New) of protobuf messages declared in a
+// .proto file, along with a func that registers a prototype of each message with an amp.Registry.
+//
+// A message is generated if it has the amp.attr option (declared in amp.proto), which declares the attr spec of the
+// message relative to amp.AttrSpec:
+//
+//	import "amp/amp.proto";
+//
+//	// Position describes a position in space and/or time using a given coordinate system.
+//	message Position {
+//	    option (amp.attr) = "Position";
+//
+// Only top-level messages are supported.  Typical use, alongside the .proto file:
+//
+//	//go:generate go run github.com/amp-3d/amp-sdk-go/cmd/amp-attrgen -register RegisterAttrs std.proto
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const ampPackage = "github.com/amp-3d/amp-sdk-go/amp"
+
+// protoFile is what is parsed from a .proto file.
+type protoFile struct {
+	Source    string
+	GoPackage string // import path given by option go_package
+	GoName    string // package name given by option go_package, if any
+	Attrs     []attrDef
+}
+
+// attrDef is a message having the amp.attr option.
+type attrDef struct {
+	Message string
+	Spec    string
+}
+
+var (
+	reAttr      = regexp.MustCompile(`^option\s+\(\s*(?:amp\s*\.\s*)?attr\s*\)\s*=\s*"([^"]*)"`)
+	reMessage   = regexp.MustCompile(`^message\s+(\w+)\s*\{?`)
+	reGoPackage = regexp.MustCompile(`^option\s+go_package\s*=\s*"([^"]+)"`)
+)
+
+// parseProto scans the top-level messages of a .proto file for the amp.attr option.
+func parseProto(r io.Reader, source string) (*protoFile, error) {
+	file := &protoFile{
+		Source: source,
+	}
+
+	depth := 0
+	message := "" // top-level message currently being scanned
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), "//")
+		line = strings.TrimSpace(line)
+
+		switch {
+		case depth == 0:
+			if m := reGoPackage.FindStringSubmatch(line); m != nil {
+				file.GoPackage, file.GoName, _ = strings.Cut(m[1], ";")
+			}
+			if line != "" && !strings.HasPrefix(line, "{") {
+				message = ""
+				if m := reMessage.FindStringSubmatch(line); m != nil {
+					message = m[1]
+				}
+			}
+		case reAttr.MatchString(line):
+			spec := reAttr.FindStringSubmatch(line)[1]
+			if depth > 1 || message == "" {
+				return nil, fmt.Errorf("%s:%d: amp.attr option is only supported on top-level messages", source, lineNum)
+			}
+			if spec == "" {
+				return nil, fmt.Errorf("%s:%d: amp.attr option of %s is empty", source, lineNum, message)
+			}
+			file.Attrs = append(file.Attrs, attrDef{
+				Message: message,
+				Spec:    spec,
+			})
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if file.GoPackage == "" {
+		return nil, fmt.Errorf("%s: missing option go_package", source)
+	}
+	return file, nil
+}
+
+var genTemplate = template.Must(template.New("attrs").Parse(`// Code generated by amp-attrgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .Qualifier}}
+	"` + ampPackage + `"
+{{- end}}
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+{{range .Attrs}}
+func (v *{{.Message}}) MarshalToStore(in []byte) (out []byte, err error) {
+	return {{$.Qualifier}}MarshalPbToStore(v, in)
+}
+
+func (v *{{.Message}}) TagSpec() tag.Spec {
+	return {{$.Qualifier}}AttrSpec.With("{{.Spec}}")
+}
+
+func (v *{{.Message}}) New() tag.Value {
+	return &{{.Message}}{}
+}
+{{end}}
+{{- if .Register}}
+// {{.Register}} registers a prototype of each attr declared in {{.Source}}.
+func {{.Register}}(reg {{.Qualifier}}Registry) {
+{{- range .Attrs}}
+	reg.RegisterPrototype({{$.Qualifier}}AttrSpec, &{{.Message}}{}, "{{.Spec}}")
+{{- end}}
+}
+{{- end}}
+`))
+
+// generate writes the Go source for the given file, where register names the registration func (or "" for none).
+func generate(file *protoFile, register string) ([]byte, error) {
+	params := struct {
+		*protoFile
+		Package   string
+		Qualifier string
+		Register  string
+	}{
+		protoFile: file,
+		Package:   file.GoName,
+		Register:  register,
+	}
+	if params.Package == "" {
+		params.Package = path.Base(file.GoPackage)
+	}
+	if file.GoPackage != ampPackage {
+		params.Qualifier = "amp."
+	}
+
+	var buf bytes.Buffer
+	if err := genTemplate.Execute(&buf, &params); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	register := flag.String("register", "", "name of the generated func that registers each attr with an amp.Registry")
+	output := flag.String("o", "", "output file (default: <name>.attrs.gen.go for <name>.proto)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: amp-attrgen [flags] file.proto\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *output, *register); err != nil {
+		fmt.Fprintf(os.Stderr, "amp-attrgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(protoPath, outPath, register string) error {
+	src, err := os.Open(protoPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	file, err := parseProto(src, filepath.Base(protoPath))
+	if err != nil {
+		return err
+	}
+	out, err := generate(file, register)
+	if err != nil {
+		return err
+	}
+	if outPath == "" {
+		outPath = strings.TrimSuffix(protoPath, filepath.Ext(protoPath)) + ".attrs.gen.go"
+	}
+	return os.WriteFile(outPath, out, 0644)
+}
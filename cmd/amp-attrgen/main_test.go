@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testProto = `syntax = "proto3";
+package demo;
+
+option go_package = "example.com/demo/v2;demo";
+
+import "amp/amp.proto";
+
+// Track is an audio track.
+message Track {
+    option (amp.attr) = "Track";
+
+    string Title = 1;
+
+    message Part {
+        int64 Offset = 1;
+    }
+    repeated Part Parts = 2;
+}
+
+// Untracked is not an attr.
+message Untracked {
+}
+
+message Playlist
+{
+    option (amp.attr) = "media.Playlist"; // spec differs from the message name
+    repeated Track Tracks = 1;
+}
+`
+
+func TestGenerate(t *testing.T) {
+	file, err := parseProto(strings.NewReader(testProto), "demo.proto")
+	if err != nil {
+		t.Fatalf("parseProto failed: %v", err)
+	}
+	if file.GoPackage != "example.com/demo/v2" || file.GoName != "demo" {
+		t.Fatalf("unexpected go_package %q;%q", file.GoPackage, file.GoName)
+	}
+	expected := []attrDef{
+		{Message: "Track", Spec: "Track"},
+		{Message: "Playlist", Spec: "media.Playlist"},
+	}
+	if len(file.Attrs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, file.Attrs)
+	}
+	for i, def := range expected {
+		if file.Attrs[i] != def {
+			t.Fatalf("expected %v, got %v", def, file.Attrs[i])
+		}
+	}
+
+	out, err := generate(file, "RegisterAttrs")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	src := string(out)
+	for _, want := range []string{
+		"package demo\n",
+		"return amp.MarshalPbToStore(v, in)",
+		`return amp.AttrSpec.With("media.Playlist")`,
+		"func (v *Track) New() tag.Value {",
+		`reg.RegisterPrototype(amp.AttrSpec, &Track{}, "Track")`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("expected generated source to contain %q:\n%s", want, src)
+		}
+	}
+
+	// Without a ";name", the package name is the last element of the import path
+	file.GoName = ""
+	if out, err = generate(file, ""); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if !strings.Contains(string(out), "package v2\n") {
+		t.Fatalf("expected package v2:\n%s", out)
+	}
+
+	// The option is only supported on top-level messages
+	bad := strings.Replace(testProto, "int64 Offset = 1;", `option (amp.attr) = "Part";`, 1)
+	if _, err := parseProto(strings.NewReader(bad), "bad.proto"); err == nil {
+		t.Fatal("expected nested option to fail")
+	}
+	bad = strings.Replace(testProto, `"Track";`, `"";`, 1)
+	if _, err := parseProto(strings.NewReader(bad), "bad.proto"); err == nil {
+		t.Fatal("expected empty option to fail")
+	}
+}
@@ -0,0 +1,293 @@
+// amp-tx inspects a stream of serialized TxMsgs, as written by TxMsg.MarshalToWriter, read from a file or stdin.
+//
+// Each tx is checked for well-formed framing (see amp.TxReader) and printed, where attr IDs are resolved to their
+// canonic attr spec via a registry of the builtin amp and std attrs.  Ops can be filtered by CellID and AttrID,
+// and a stream can be converted to and from the canonical JSON form of a TxMsg (see amp.TxJSON):
+//
+//	amp-tx capture.bin                          # print each tx and its ops
+//	amp-tx -out json capture.bin > capture.json # convert to JSON
+//	amp-tx -in json -out tx -o capture.bin capture.json
+//	amp-tx -attr amp.tag.attr.Login capture.bin # print only Login ops
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+// Input and output formats
+const (
+	formatTx   = "tx"   // framed TxMsgs
+	formatJSON = "json" // consecutive amp.TxJSON objects
+	formatText = "text" // human readable (output only)
+	formatNone = "none" // validate only (output only)
+)
+
+type options struct {
+	inFormat  string
+	outFormat string
+	cellID    tag.ID // if set, only ops on this cell are output
+	attrID    tag.ID // if set, only ops on this attr are output
+}
+
+func main() {
+	var opts options
+	var cell, attr, outPath string
+	flag.StringVar(&opts.inFormat, "in", formatTx, "input format: tx or json")
+	flag.StringVar(&opts.outFormat, "out", formatText, "output format: text, json, tx, or none")
+	flag.StringVar(&cell, "cell", "", "only output ops having this CellID (Base32)")
+	flag.StringVar(&attr, "attr", "", "only output ops having this AttrID (Base32 or attr spec, e.g. amp.tag.attr.Login)")
+	flag.StringVar(&outPath, "o", "", "output file (default: stdout)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: amp-tx [flags] [file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	err := func() error {
+		var err error
+		if cell != "" {
+			if opts.cellID, err = tag.FromBase32(cell); err != nil {
+				return err
+			}
+		}
+		if attr != "" {
+			if opts.attrID, err = tag.FromBase32(attr); err != nil {
+				opts.attrID = tag.Spec{}.With(attr).ID
+			}
+		}
+
+		var in io.Reader = os.Stdin
+		switch flag.NArg() {
+		case 0:
+		case 1:
+			file, err := os.Open(flag.Arg(0))
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			in = file
+		default:
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		var out io.Writer = os.Stdout
+		if outPath != "" {
+			file, err := os.Create(outPath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		return run(opts, in, out)
+	}()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "amp-tx: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newRegistry returns a registry of the attrs known to this tool.
+func newRegistry() amp.Registry {
+	reg := amp.NewRegistry()
+	amp.RegisterBuiltinTypes(reg)
+	std.RegisterAttrs(reg)
+	return reg
+}
+
+// run reads txs from in and writes them to out in the given output format.
+func run(opts options, in io.Reader, out io.Writer) error {
+	reg := newRegistry()
+
+	var next func() (*amp.TxMsg, *amp.TxHeader, error)
+	switch opts.inFormat {
+	case formatTx:
+		next = (&txStream{src: in}).next
+	case formatJSON:
+		dec := json.NewDecoder(in)
+		dec.DisallowUnknownFields()
+		next = (&jsonStream{dec: dec, reg: reg}).next
+	default:
+		return fmt.Errorf("unknown input format %q", opts.inFormat)
+	}
+	switch opts.outFormat {
+	case formatText, formatJSON, formatTx, formatNone:
+	default:
+		return fmt.Errorf("unknown output format %q", opts.outFormat)
+	}
+
+	w := bufio.NewWriter(out)
+	p := printer{
+		options: opts,
+		reg:     reg,
+		w:       w,
+	}
+	for txNum := 0; ; txNum++ {
+		tx, header, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			w.Flush()
+			return fmt.Errorf("tx %d: %w", txNum, err)
+		}
+		err = p.output(txNum, p.filter(tx), header)
+		tx.ReleaseRef()
+		if err != nil {
+			w.Flush()
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// txStream reads framed TxMsgs, tracking the offset of each tx in the stream.
+type txStream struct {
+	src    io.Reader
+	offset int64
+	reader amp.TxReader
+}
+
+func (s *txStream) next() (*amp.TxMsg, *amp.TxHeader, error) {
+	header := &amp.TxHeader{}
+	if n, err := io.ReadFull(s.src, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = fmt.Errorf("at offset %d: truncated TxHeader (%d bytes)", s.offset, n)
+		}
+		return nil, nil, err
+	}
+
+	// TxReader checks the header and reads the rest of the tx
+	tx, err := s.reader.ReadTxMsg(io.MultiReader(bytes.NewReader(header[:]), s.src))
+	if err != nil {
+		return nil, nil, fmt.Errorf("at offset %d: %w", s.offset, err)
+	}
+	s.offset += int64(header.TxBodyLen() + header.TxDataLen())
+	return tx, header, nil
+}
+
+// jsonStream reads consecutive amp.TxJSON objects.
+type jsonStream struct {
+	dec *json.Decoder
+	reg amp.Registry
+}
+
+func (s *jsonStream) next() (*amp.TxMsg, *amp.TxHeader, error) {
+	var txJSON amp.TxJSON
+	if err := s.dec.Decode(&txJSON); err != nil {
+		if err != io.EOF {
+			err = amp.ErrCode_MalformedTx.Errorf("bad JSON: %v", err)
+		}
+		return nil, nil, err
+	}
+	tx, err := txJSON.ToTxMsg(s.reg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, nil, nil
+}
+
+type printer struct {
+	options
+	reg   amp.Registry
+	w     *bufio.Writer
+	scrap []byte
+}
+
+// filter returns the ops of tx matching the CellID and AttrID filters, or nil if none match.
+func (p *printer) filter(tx *amp.TxMsg) *amp.TxMsg {
+	if p.cellID.IsNil() && p.attrID.IsNil() {
+		return tx
+	}
+
+	filtered := &amp.TxMsg{
+		TxInfo: tx.TxInfo,
+	}
+	for _, op := range tx.Ops {
+		if (p.cellID.IsSet() && op.CellID != p.cellID) || (p.attrID.IsSet() && op.AttrID != p.attrID) {
+			continue
+		}
+		filtered.MarshalOpWithBuf(&op, tx.DataStore[op.DataOfs:op.DataOfs+op.DataLen])
+	}
+	if len(filtered.Ops) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+func (p *printer) output(txNum int, tx *amp.TxMsg, header *amp.TxHeader) error {
+	if tx == nil {
+		return nil
+	}
+
+	switch p.outFormat {
+	case formatTx:
+		return tx.MarshalToWriter(&p.scrap, p.w)
+	case formatJSON:
+		buf, err := amp.MarshalTxJSON(tx, p.reg)
+		if err != nil {
+			return err
+		}
+		p.w.Write(buf)
+		p.w.WriteByte('\n')
+	case formatText:
+		return p.printText(txNum, tx, header)
+	}
+	return nil
+}
+
+func (p *printer) printText(txNum int, tx *amp.TxMsg, header *amp.TxHeader) error {
+	txJSON, err := amp.TxToJSON(tx, p.reg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.w, "tx %d: %s  genesis %s", txNum, txJSON.Status, txJSON.GenesisID)
+	if txJSON.ContextID != "" {
+		fmt.Fprintf(p.w, "  context %s", txJSON.ContextID)
+	}
+	fmt.Fprintf(p.w, "  ops %d  data %d\n", len(tx.Ops), len(tx.DataStore))
+	if header != nil {
+		fmt.Fprintf(p.w, "  header: version %d  body %d  data %d  codec %d  accepts %#02x\n",
+			header[3], header.TxBodyLen(), header.TxDataLen(), header.DataCodec(), header[13])
+	}
+
+	for i, op := range txJSON.Ops {
+		fmt.Fprintf(p.w, "  [%d] %s  cell %s  attr %s", i, op.OpCode, op.CellID, p.attrName(tx.Ops[i].AttrID))
+		if op.SI != "" {
+			fmt.Fprintf(p.w, "  SI %s", op.SI)
+		}
+		if op.EditID != "" {
+			fmt.Fprintf(p.w, "  edit %s", op.EditID)
+		}
+		switch {
+		case op.Value != nil:
+			fmt.Fprintf(p.w, "\n      %s\n", op.Value)
+		case op.Data != nil:
+			fmt.Fprintf(p.w, "\n      (%d bytes) %x\n", len(op.Data), op.Data)
+		default:
+			p.w.WriteByte('\n')
+		}
+	}
+	return nil
+}
+
+// attrName returns the canonic attr spec of the given attr ID, or its Base32 form if not registered.
+func (p *printer) attrName(attrID tag.ID) string {
+	def, err := p.reg.GetAttrDef(attrID)
+	if err != nil || def.Canonic == "" {
+		return attrID.Base32()
+	}
+	return def.Canonic
+}
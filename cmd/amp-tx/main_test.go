@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/amp-3d/amp-sdk-go/amp"
+	"github.com/amp-3d/amp-sdk-go/amp/std"
+	"github.com/amp-3d/amp-sdk-go/stdlib/tag"
+)
+
+func TestInspect(t *testing.T) {
+	cellID := tag.ID{0, 0, 77}
+
+	var stream bytes.Buffer
+	var scrap []byte
+	{
+		tx, err := amp.MarshalAttr(amp.MetaNodeID, tag.ID{}, &amp.Login{
+			UserLabel: "anon",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.MarshalToWriter(&scrap, &stream)
+
+		tx = amp.NewTxMsg(true)
+		tx.Status = amp.OpStatus_Synced
+		tx.Upsert(cellID, std.CellProperties.ID, std.CellLabel, &amp.Tag{Text: "Blue in Green"})
+		tx.Upsert(cellID, (&std.Position{}).TagSpec().ID, tag.ID{}, &std.Position{Q: 3})
+		tx.MarshalToWriter(&scrap, &stream)
+	}
+
+	inspect := func(opts options, in []byte) (string, error) {
+		var out bytes.Buffer
+		err := run(opts, bytes.NewReader(in), &out)
+		return out.String(), err
+	}
+
+	text, err := inspect(options{inFormat: formatTx, outFormat: formatText}, stream.Bytes())
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	for _, want := range []string{
+		"tx 1: OpStatus_Synced",
+		"attr amp.tag.attr.Login",
+		"attr amp.tag.attr.Position",
+		`"UserLabel":"anon"`,
+		"cell " + cellID.Base32(),
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected output to contain %q:\n%s", want, text)
+		}
+	}
+
+	// tx => JSON => tx
+	js, err := inspect(options{inFormat: formatTx, outFormat: formatJSON}, stream.Bytes())
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	txs, err := inspect(options{inFormat: formatJSON, outFormat: formatTx}, []byte(js))
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !bytes.Equal([]byte(txs), stream.Bytes()) {
+		t.Fatalf("JSON conversion did not restore the stream:\n%s", js)
+	}
+
+	// Filter by attr spec
+	opts := options{
+		inFormat:  formatTx,
+		outFormat: formatText,
+		attrID:    tag.Spec{}.With("amp.tag.attr.Position").ID,
+	}
+	if text, err = inspect(opts, stream.Bytes()); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if strings.Contains(text, "tx 0") || strings.Contains(text, "cell-properties") || !strings.Contains(text, "Position") {
+		t.Fatalf("unexpected filtered output:\n%s", text)
+	}
+
+	// Bad framing is reported
+	truncated := stream.Bytes()[:stream.Len()-3]
+	if _, err = inspect(options{inFormat: formatTx, outFormat: formatNone}, truncated); err == nil || !strings.HasPrefix(err.Error(), "tx 1: at offset") {
+		t.Fatalf("expected truncated stream to fail, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/amp-3d/amp-sdk-go/stdlib/bufs"
@@ -186,9 +187,16 @@ func FromTime(t time.Time, addEntropy bool) ID {
 	}
 
 	if addEntropy {
-		gTagSeed = 377377733*ns_f64 ^ gTagSeed
-		tag[1] ^= gTagSeed & EntropyMask
-		tag[2] ^= gTagSeed * ns_f64
+		var seed uint64
+		for {
+			prev := gTagSeed.Load()
+			seed = 377377733*ns_f64 ^ prev
+			if gTagSeed.CompareAndSwap(prev, seed) {
+				break
+			}
+		}
+		tag[1] ^= seed & EntropyMask
+		tag[2] ^= seed * ns_f64
 	}
 
 	return tag
@@ -340,6 +348,16 @@ func (tag ID) Base32() string {
 	return str
 }
 
+// FromBase32 parses a tag.ID in the canonic Base32 form returned by ID.Base32().
+func FromBase32(str string) (ID, error) {
+	var buf [25]byte
+	n, err := bufs.Base32Encoding.Decode(buf[:], []byte(str))
+	if err != nil || n != len(buf) || buf[0] != 0 || len(str) != bufs.Base32Encoding.EncodedLen(n) {
+		return Nil, fmt.Errorf("tag: bad Base32 tag.ID %q", str)
+	}
+	return FromBytes(buf[1:])
+}
+
 func (tag ID) Base16() string {
 	buf := make([]byte, 0, 48)
 	tagBytes := tag.AppendTo(buf)
@@ -396,10 +414,15 @@ func IntsToID(x0 int64, x1, x2 uint64) ID {
 
 type Key [24]byte
 
-var gTagSeed = uint64(0x3773000000003773)
+var gTagSeed = func() *atomic.Uint64 {
+	seed := &atomic.Uint64{}
+	seed.Store(0x3773000000003773)
+	return seed
+}()
 
 var (
-	Nil = ID{}
+	Nil      = ID{}
+	Wildcard = ID{0x1, 0x1, 0x1} // see ID.IsWildcard()
 )
 
 func FromBytes(in []byte) (tag ID, err error) {
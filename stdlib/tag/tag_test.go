@@ -39,6 +39,12 @@ func TestTag(t *testing.T) {
 	if tid.Base32() != "00000000000000vrfxvrfxvrfxvj4e2qg2ectrrh" {
 		t.Errorf("tag.ID.Base32() failed")
 	}
+	if id, err := tag.FromBase32(tid.Base32()); err != nil || id != tid {
+		t.Errorf("tag.FromBase32() failed: %v", err)
+	}
+	if _, err := tag.FromBase32("zz" + tid.Base32()[2:]); err == nil {
+		t.Errorf("tag.FromBase32() accepted an out of range ID")
+	}
 	if b16 := tid.Base16(); b16 != "00000000000000037777777777777777123456789abcdef0" {
 		t.Errorf("tag.ID.Base16() failed: %v", b16)
 	}
@@ -78,7 +78,9 @@ func (p *ctx) CloseWhenIdle(delay time.Duration) {
 		var timer *time.Timer
 
 		for idleClose := true; idleClose; {
+			p.subsMu.Lock()
 			p.idle = true
+			p.subsMu.Unlock()
 			p.busy.Wait() // wait until there is a chance of catching ctx idle
 
 			retry := false
@@ -214,7 +216,7 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 	if p != nil {
 		var err error
 		p.subsMu.Lock()
-		if p.state == Running {
+		if atomic.LoadInt32(&p.state) == Running {
 			p.busy.Add(1)
 			p.idle = false
 			p.subs = append(p.subs, child)
@@ -282,7 +284,7 @@ func (p *ctx) StartChild(task *Task) (Context, error) {
 		}
 
 		// Move to Closed state now that all all that remains is the OnClosed callback and release of the chClosed chan.
-		child.state = Closed
+		atomic.StoreInt32(&child.state, Closed)
 		if child.task.OnClosed != nil {
 			child.task.OnClosed()
 		}